@@ -0,0 +1,235 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-the-way/sg"
+)
+
+type (
+	AggregateOperation[E Entity] interface {
+		WithContext(ctx context.Context) AggregateOperation[E]
+		Join(fields ...string) AggregateOperation[E]
+		IfWhere(cond bool, wheres ...sg.Ge) AggregateOperation[E]
+		Where(wheres ...sg.Ge) AggregateOperation[E]
+		GroupBy(fields ...string) AggregateOperation[E]
+		Having(havings ...sg.Ge) AggregateOperation[E]
+		OrderBy(orderBys ...sg.Ge) AggregateOperation[E]
+		Count(alias string) AggregateOperation[E]
+		CountDistinct(field, alias string) AggregateOperation[E]
+		Sum(field, alias string) AggregateOperation[E]
+		Avg(field, alias string) AggregateOperation[E]
+		Min(field, alias string) AggregateOperation[E]
+		Max(field, alias string) AggregateOperation[E]
+		Scan(e E, dest any) error
+		Maps(e E) ([]map[string]any, error)
+		ToSQL(e E) (sqlStr string, ps []any)
+	}
+	aggregateOperation[E Entity] struct {
+		sel               *selectOperation[E]
+		groupBys          []string
+		aggregates        []*aggregate
+		havings, orderBys []sg.Ge
+	}
+	aggregate struct {
+		fn, field, alias string
+		distinct         bool
+	}
+)
+
+var (
+	errAggregateNoColumn = errors.New("anorm: aggregate has no group by field or aggregate")
+)
+
+func Aggregate[E Entity](e E) AggregateOperation[E] {
+	return New(e).OpsForAggregate()
+}
+
+func AggregateWithDs[E Entity](e E, ds string) AggregateOperation[E] {
+	return NewWithDS(e, ds).OpsForAggregate()
+}
+
+func newAggregateOperation[E Entity](o *Orm[E]) *aggregateOperation[E] {
+	return &aggregateOperation[E]{sel: newSelectOperation(o), groupBys: make([]string, 0), aggregates: make([]*aggregate, 0), havings: make([]sg.Ge, 0), orderBys: make([]sg.Ge, 0)}
+}
+
+// WithContext set the context passed to the entity hooks and the interceptors
+func (o *aggregateOperation[E]) WithContext(ctx context.Context) AggregateOperation[E] {
+	o.sel.WithContext(ctx)
+	return o
+}
+
+// Join enable join query, only the join fields if listed, the join fields can be grouped and aggregated
+func (o *aggregateOperation[E]) Join(fields ...string) AggregateOperation[E] {
+	o.sel.Join(fields...)
+	return o
+}
+
+// IfWhere if cond is true append wheres
+func (o *aggregateOperation[E]) IfWhere(cond bool, wheres ...sg.Ge) AggregateOperation[E] {
+	o.sel.IfWhere(cond, wheres...)
+	return o
+}
+
+// Where append wheres
+func (o *aggregateOperation[E]) Where(wheres ...sg.Ge) AggregateOperation[E] {
+	o.sel.Where(wheres...)
+	return o
+}
+
+// GroupBy append group by fields, accepts field or column names, the fields are selected as the field name
+func (o *aggregateOperation[E]) GroupBy(fields ...string) AggregateOperation[E] {
+	o.groupBys = append(o.groupBys, fields...)
+	return o
+}
+
+// Having append havings
+func (o *aggregateOperation[E]) Having(havings ...sg.Ge) AggregateOperation[E] {
+	o.havings = append(o.havings, havings...)
+	return o
+}
+
+// OrderBy append OrderBys
+func (o *aggregateOperation[E]) OrderBy(orderBys ...sg.Ge) AggregateOperation[E] {
+	o.orderBys = append(o.orderBys, orderBys...)
+	return o
+}
+
+// Count select COUNT(0) AS alias
+func (o *aggregateOperation[E]) Count(alias string) AggregateOperation[E] {
+	o.aggregates = append(o.aggregates, &aggregate{fn: "COUNT", alias: alias})
+	return o
+}
+
+// CountDistinct select COUNT(DISTINCT field) AS alias
+func (o *aggregateOperation[E]) CountDistinct(field, alias string) AggregateOperation[E] {
+	o.aggregates = append(o.aggregates, &aggregate{fn: "COUNT", field: field, alias: alias, distinct: true})
+	return o
+}
+
+// Sum select SUM(field) AS alias
+func (o *aggregateOperation[E]) Sum(field, alias string) AggregateOperation[E] {
+	o.aggregates = append(o.aggregates, &aggregate{fn: "SUM", field: field, alias: alias})
+	return o
+}
+
+// Avg select AVG(field) AS alias
+func (o *aggregateOperation[E]) Avg(field, alias string) AggregateOperation[E] {
+	o.aggregates = append(o.aggregates, &aggregate{fn: "AVG", field: field, alias: alias})
+	return o
+}
+
+// Min select MIN(field) AS alias
+func (o *aggregateOperation[E]) Min(field, alias string) AggregateOperation[E] {
+	o.aggregates = append(o.aggregates, &aggregate{fn: "MIN", field: field, alias: alias})
+	return o
+}
+
+// Max select MAX(field) AS alias
+func (o *aggregateOperation[E]) Max(field, alias string) AggregateOperation[E] {
+	o.aggregates = append(o.aggregates, &aggregate{fn: "MAX", field: field, alias: alias})
+	return o
+}
+
+func (o *aggregateOperation[E]) getColumn(field string) (sg.C, error) {
+	column, have := o.sel.getFieldColumn(field)
+	if !have {
+		return "", errUnknownField(o.sel.orm.entity, field)
+	}
+	return column, nil
+}
+
+func (o *aggregateOperation[E]) getAggregateBuilder(entity E) (string, []any, error) {
+	if o.sel.err != nil {
+		return "", nil, o.sel.err
+	}
+	columns, groupBys := make([]sg.Ge, 0), make([]sg.Ge, 0)
+	for _, f := range o.groupBys {
+		column, err := o.getColumn(f)
+		if err != nil {
+			return "", nil, err
+		}
+		columns = append(columns, sg.Alias(column, o.sel.getFieldName(f)))
+		groupBys = append(groupBys, column)
+	}
+	for _, a := range o.aggregates {
+		// COUNT(0) AS c, COUNT(DISTINCT t.name) AS c, SUM(t.age) AS s
+		arg := "0"
+		if a.field != "" {
+			column, err := o.getColumn(a.field)
+			if err != nil {
+				return "", nil, err
+			}
+			arg = string(column)
+		}
+		if a.distinct {
+			arg = "DISTINCT " + arg
+		}
+		columns = append(columns, sg.Alias(sg.C(fmt.Sprintf("%s(%s)", a.fn, arg)), a.alias))
+	}
+	if len(columns) <= 0 {
+		return "", nil, errAggregateNoColumn
+	}
+	ges := []sg.Ge{
+		sg.Select(columns...),
+		sg.From(sg.Alias(o.sel.getTableName(), "t")),
+	}
+	if _, refJoins := o.sel.getJoinRef(o.sel.joinFields); len(refJoins) > 0 {
+		ges = append(ges, sg.NewJoiner(refJoins, " ", "", "", false))
+	}
+	ges = append(ges,
+		sg.Where(sg.AndGroup(o.sel.getWheres(entity)...)),
+		sg.GroupBy(groupBys...),
+		sg.Having(sg.AndGroup(o.havings...)),
+		sg.OrderBy(o.orderBys...),
+	)
+	sqlStr, ps := sg.NewJoiner(ges, " ", "", "", false).SQL()
+	return sqlStr, ps, nil
+}
+
+// Scan select the group by fields and the aggregates, scan into dest
+//
+// dest is a pointer to []T or []map[string]any, the columns are named by the field names and aliases, mapped like Scan
+func (o *aggregateOperation[E]) Scan(e E, dest any) error {
+	sqlStr, ps, err := o.getAggregateBuilder(e)
+	if err != nil {
+		return err
+	}
+	rows, err := o.sel.orm.query("OpsForAggregate.Scan", sqlStr, ps)
+	if err != nil {
+		return err
+	}
+	return scanSlice(rows, dest)
+}
+
+// Maps select the group by fields and the aggregates, return the rows keyed by the field names and aliases
+func (o *aggregateOperation[E]) Maps(e E) ([]map[string]any, error) {
+	ms := make([]map[string]any, 0)
+	if err := o.Scan(e, &ms); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// ToSQL return the SQL and arguments Scan would execute, without touching the datasource
+//
+// Return empty SQL if the group by fields or aggregates invalid
+func (o *aggregateOperation[E]) ToSQL(e E) (sqlStr string, ps []any) {
+	sqlStr, ps, err := o.getAggregateBuilder(e)
+	if err != nil {
+		return "", nil
+	}
+	return sqlStr, unmaskArgs(ps)
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"github.com/go-the-way/sg"
+	"reflect"
+	"testing"
+)
+
+func TestAggregateToSQL(t *testing.T) {
+	{
+		sqlStr, ps := Aggregate(new(userEntity)).
+			Where(sg.Gt("age", 0)).
+			GroupBy("Name", "address").
+			Count("C").
+			CountDistinct("Phone", "Phones").
+			Sum("Age", "SumAge").
+			Having(sg.Gt("C", 1)).
+			OrderBy(sg.Desc(sg.C("C"))).
+			ToSQL(&userEntity{Name: "coco"})
+		if sqlStr != "SELECT t.name AS Name, t.address AS Address, COUNT(0) AS C, COUNT(DISTINCT t.phone) AS Phones, SUM(t.age) AS SumAge FROM user_entity AS t WHERE ((age > ?) AND (name = ?)) GROUP BY t.name, t.address HAVING ((C > ?)) ORDER BY C DESC" || !reflect.DeepEqual(ps, []any{0, "coco", 1}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, _ := Aggregate(new(userEntity)).Avg("Age", "A").Min("age", "Mi").Max("Age", "Ma").ToSQL(nil)
+		if sqlStr != "SELECT AVG(t.age) AS A, MIN(t.age) AS Mi, MAX(t.age) AS Ma FROM user_entity AS t" {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		if sqlStr, _ := Aggregate(new(userEntity)).Sum("Unknown", "S").ToSQL(nil); sqlStr != "" {
+			t.Fatal("test failed!")
+		}
+		if sqlStr, _ := Aggregate(new(userEntity)).ToSQL(nil); sqlStr != "" {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestAggregateScan(t *testing.T) {
+	truncateTestTable()
+	_ = insertUserEntity("coco", 10, "wuhan", "130")
+	_ = insertUserEntity("coco", 20, "wuhan", "131")
+	_ = insertUserEntity("hugo", 30, "beijing", "132")
+	type result struct {
+		Name   string
+		C      int
+		SumAge int
+		AvgAge float64
+	}
+	rs := make([]result, 0)
+	if err := Aggregate(new(userEntity)).GroupBy("Name").Count("C").Sum("Age", "SumAge").Avg("Age", "AvgAge").OrderBy(sg.C("Name")).Scan(nil, &rs); err != nil {
+		t.Fatalf("TestAggregateScan failed: %v\n", err)
+	}
+	if !reflect.DeepEqual(rs, []result{{"coco", 2, 30, 15}, {"hugo", 1, 30, 30}}) {
+		t.Fatal("TestAggregateScan failed!")
+	}
+	if ms, err := Aggregate(new(userEntity)).GroupBy("Name").Count("C").Having(sg.Gt("C", 1)).Maps(nil); err != nil {
+		t.Fatalf("TestAggregateScan failed: %v\n", err)
+	} else if len(ms) != 1 || ms[0]["Name"] != "coco" || ms[0]["C"] != int64(2) {
+		t.Fatal("TestAggregateScan failed!")
+	}
+	if err := Aggregate(new(userEntity)).GroupBy("Name").Count("C").Scan(nil, &[]struct{ Name string }{}); err == nil {
+		t.Fatal("TestAggregateScan failed!")
+	}
+}
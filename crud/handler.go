@@ -0,0 +1,461 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-the-way/anorm"
+	"github.com/go-the-way/anorm/search"
+	"github.com/go-the-way/sg"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Action defines the CRUD action of the request
+type Action string
+
+const (
+	ActionList   Action = "list"   // GET /
+	ActionGet    Action = "get"    // GET /{id}
+	ActionCreate Action = "create" // POST /
+	ActionUpdate Action = "update" // PUT /{id} sets all the writable fields, PATCH /{id} the fields present in the body
+	ActionDelete Action = "delete" // DELETE /{id}
+)
+
+var (
+	// ErrUnauthorized defines the error Authorize may return, responds 401
+	ErrUnauthorized = errors.New("crud: unauthorized")
+	// ErrForbidden defines the error Authorize may return, responds 403, the other errors are wrapped by it
+	ErrForbidden = errors.New("crud: forbidden")
+
+	errNotFound         = errors.New("crud: not found")
+	errMethodNotAllowed = errors.New("crud: method not allowed")
+	errInternal         = errors.New("crud: internal error")
+	errSinglePK         = func(entity anorm.Entity) error {
+		return errors.New(fmt.Sprintf("crud: entity [%T] requires exactly one primary key", entity))
+	}
+)
+
+type (
+	// Options defines the hooks of the handler, all optional
+	Options[E anorm.Entity] struct {
+		// Authorize called before each action, a non-nil error responds 403, or 401 if it is ErrUnauthorized
+		Authorize func(r *http.Request, action Action) error
+		// Writable defines the fields or columns create and update may write, the primary key not allowed,
+		// all fields if empty, update sets the anorm.UpdateFields then. The fields of `json:"-"` never updated
+		Writable []string
+		// Validate called before create and update with the decoded entity, a non-nil error responds 400
+		//
+		// The create is not scoped by Select, set the scoped fields of the entity here, e.g. the tenant
+		Validate func(r *http.Request, action Action, e E) error
+		// Select called with the select operation of list, get, update and delete, e.g. append the tenant wheres
+		//
+		// The wheres are applied to the update and delete too, see anorm.Wheres, so they must be of the entity table.
+		// The create is not scoped, see Validate
+		Select func(r *http.Request, op anorm.SelectOperation[E]) anorm.SelectOperation[E]
+		// Filter return a new filter struct pointer for list, declared by the anorm.FilterTag
+		Filter func() any
+		// Search defines the search options of list, search.DefaultOptions if nil
+		Search *search.Options
+	}
+	handler[E anorm.Entity] struct {
+		entity  E
+		opts    *Options[E]
+		pkField string
+		indexes []int         // the writable field indexes, all fields writable if empty
+		updates []updateField // the fields update sets
+	}
+	// updateField defines the field update sets
+	updateField struct {
+		column string
+		name   string // the JSON name
+	}
+	// errorBody defines the JSON body of the error response
+	errorBody struct {
+		Error string `json:"error"`
+	}
+)
+
+// Handler return a JSON CRUD http.Handler of the registered entity, mount it by http.StripPrefix
+//
+//	GET    /      list, see search.FromHTTP
+//	POST   /      create
+//	GET    /{id}  get
+//	PUT    /{id}  update all the writable fields
+//	PATCH  /{id}  update the writable fields present in the body
+//	DELETE /{id}  delete
+//
+// It panics if the entity has not exactly one primary key, or Writable has unknown fields or the primary key
+func Handler[E anorm.Entity](e E, opts *Options[E]) http.Handler {
+	if opts == nil {
+		opts = &Options[E]{}
+	}
+	pks := anorm.PKFields(e)
+	if len(pks) != 1 {
+		panic(errSinglePK(e))
+	}
+	h := &handler[E]{entity: e, opts: opts, pkField: pks[0]}
+	pkColumn, _ := anorm.FieldColumn(e, pks[0])
+	writableMap := make(map[string]struct{}, len(opts.Writable))
+	for _, f := range opts.Writable {
+		column, have := anorm.FieldColumn(e, f)
+		if !have {
+			panic(errors.New(fmt.Sprintf("crud: unknown writable field [%s] of entity [%T]", f, e)))
+		}
+		if column == pkColumn {
+			panic(errors.New(fmt.Sprintf("crud: writable field [%s] is the primary key of entity [%T]", f, e)))
+		}
+		writableMap[column] = struct{}{}
+	}
+	updateMap := writableMap
+	if len(opts.Writable) <= 0 {
+		updateMap = make(map[string]struct{}, 0)
+		for _, f := range anorm.UpdateFields(e) {
+			column, _ := anorm.FieldColumn(e, f)
+			updateMap[column] = struct{}{}
+		}
+	}
+	rt := reflect.TypeOf(e).Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		column, have := anorm.FieldColumn(e, rt.Field(i).Name)
+		if !have {
+			continue
+		}
+		if _, writable := writableMap[column]; writable {
+			h.indexes = append(h.indexes, i)
+		}
+		// the fields skipped by `json:"-"` never decoded, update keeps them
+		if _, update := updateMap[column]; update {
+			if name := getJSONName(rt.Field(i)); name != "" {
+				h.updates = append(h.updates, updateField{column, name})
+			}
+		}
+	}
+	return h
+}
+
+// getJSONName return the JSON name of the field, empty if the field is skipped by `json:"-"`
+func getJSONName(sf reflect.StructField) string {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return sf.Name
+}
+
+func (h *handler[E]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(r.URL.Path, "/")
+	if strings.Contains(id, "/") {
+		h.error(w, errNotFound)
+		return
+	}
+	action, err := getAction(r.Method, id != "")
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+	if a := h.opts.Authorize; a != nil {
+		if err = a(r, action); err != nil {
+			if !errors.Is(err, ErrUnauthorized) {
+				err = fmt.Errorf("%w: %v", ErrForbidden, err)
+			}
+			h.error(w, err)
+			return
+		}
+	}
+	switch action {
+	case ActionList:
+		h.list(w, r)
+	case ActionCreate:
+		h.create(w, r)
+	case ActionGet:
+		if e, err := h.get(r, id); err != nil {
+			h.error(w, err)
+		} else {
+			h.write(w, http.StatusOK, e)
+		}
+	case ActionUpdate:
+		h.update(w, r, id)
+	case ActionDelete:
+		h.delete(w, r, id)
+	}
+}
+
+func getAction(method string, item bool) (Action, error) {
+	switch {
+	case method == http.MethodGet && !item:
+		return ActionList, nil
+	case method == http.MethodPost && !item:
+		return ActionCreate, nil
+	case method == http.MethodGet:
+		return ActionGet, nil
+	case method == http.MethodPut || method == http.MethodPatch:
+		if item {
+			return ActionUpdate, nil
+		}
+	case method == http.MethodDelete:
+		if item {
+			return ActionDelete, nil
+		}
+	}
+	return "", errMethodNotAllowed
+}
+
+func (h *handler[E]) list(w http.ResponseWriter, r *http.Request) {
+	var filter any
+	if f := h.opts.Filter; f != nil {
+		filter = f()
+	}
+	req, err := search.FromHTTP(r, filter)
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+	result, err := search.Page(h.selectOp(r), h.entity, req, h.opts.Search)
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+	h.write(w, http.StatusOK, result)
+}
+
+func (h *handler[E]) create(w http.ResponseWriter, r *http.Request) {
+	e, _, err := h.decode(r, ActionCreate)
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+	if err = anorm.Insert(h.entity).WithContext(r.Context()).One(e); err != nil {
+		h.error(w, err)
+		return
+	}
+	h.write(w, http.StatusCreated, e)
+}
+
+func (h *handler[E]) update(w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := h.get(r, id); err != nil {
+		h.error(w, err)
+		return
+	}
+	e, keys, err := h.decode(r, ActionUpdate)
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+	if err = h.setPK(e, id); err != nil {
+		h.error(w, err)
+		return
+	}
+	if columns := h.getUpdateColumns(r.Method, keys); len(columns) > 0 {
+		op, err2 := h.updateOp(r, columns)
+		if err2 != nil {
+			h.error(w, err2)
+			return
+		}
+		if _, err = op.UpByPK(e); err != nil {
+			h.error(w, err)
+			return
+		}
+	}
+	if e, err = h.get(r, id); err != nil {
+		h.error(w, err)
+		return
+	}
+	h.write(w, http.StatusOK, e)
+}
+
+func (h *handler[E]) delete(w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := h.get(r, id); err != nil {
+		h.error(w, err)
+		return
+	}
+	// the loaded entity deletes by all the non-zero fields, delete by the primary key only
+	pe := h.newEntity()
+	if err := h.setPK(pe, id); err != nil {
+		h.error(w, err)
+		return
+	}
+	op, err := h.deleteOp(r)
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+	if _, err = op.Del(pe); err != nil {
+		h.error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getUpdateColumns return the columns update sets, PATCH sets the fields present in the body only
+func (h *handler[E]) getUpdateColumns(method string, keys map[string]struct{}) []string {
+	columns := make([]string, 0, len(h.updates))
+	for _, f := range h.updates {
+		if _, have := keys[strings.ToLower(f.name)]; have || method != http.MethodPatch {
+			columns = append(columns, f.column)
+		}
+	}
+	return columns
+}
+
+// updateOp return the update operation sets the columns, scoped by the wheres of the Select hook
+func (h *handler[E]) updateOp(r *http.Request, columns []string) (anorm.UpdateOperation[E], error) {
+	wheres, err := anorm.Wheres(h.selectOp(r))
+	if err != nil {
+		return nil, err
+	}
+	op := anorm.Update(h.entity).WithContext(r.Context()).Where(wheres...)
+	for _, column := range columns {
+		op.Set(sg.C(column))
+	}
+	return op, nil
+}
+
+// deleteOp return the delete operation scoped by the wheres of the Select hook
+func (h *handler[E]) deleteOp(r *http.Request) (anorm.DeleteOperation[E], error) {
+	wheres, err := anorm.Wheres(h.selectOp(r))
+	if err != nil {
+		return nil, err
+	}
+	return anorm.Delete(h.entity).WithContext(r.Context()).Where(wheres...), nil
+}
+
+// get select the entity of the id, visible by the Select hook
+func (h *handler[E]) get(r *http.Request, id string) (oe E, err error) {
+	pe := h.newEntity()
+	if err = h.setPK(pe, id); err != nil {
+		return
+	}
+	if oe, err = h.selectOp(r).One(pe); err != nil {
+		return
+	}
+	if reflect.ValueOf(oe).IsNil() {
+		err = errNotFound
+	}
+	return
+}
+
+// selectOp return the select operation of the request context, scoped by the Select hook
+func (h *handler[E]) selectOp(r *http.Request) anorm.SelectOperation[E] {
+	op := anorm.Select(h.entity).WithContext(r.Context())
+	if s := h.opts.Select; s != nil {
+		op = s(r, op)
+	}
+	return op
+}
+
+// decode decode the JSON body into a new entity, keep the writable fields only, clear the primary key of create, then validate
+//
+// The keys are the lower-case keys present in the body
+func (h *handler[E]) decode(r *http.Request, action Action) (E, map[string]struct{}, error) {
+	e := h.newEntity()
+	bs, err := io.ReadAll(r.Body)
+	if err != nil {
+		return e, nil, err
+	}
+	raw := make(map[string]json.RawMessage, 0)
+	if err = json.Unmarshal(bs, e); err == nil {
+		err = json.Unmarshal(bs, &raw)
+	}
+	if err != nil {
+		return e, nil, fmt.Errorf("%w: %v", search.ErrInvalidRequest, err)
+	}
+	keys := make(map[string]struct{}, len(raw))
+	for k := range raw {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+	if len(h.indexes) > 0 {
+		we := h.newEntity()
+		src, dst := reflect.ValueOf(e).Elem(), reflect.ValueOf(we).Elem()
+		for _, i := range h.indexes {
+			dst.Field(i).Set(src.Field(i))
+		}
+		e = we
+	}
+	// create never writes the primary key, even if all fields writable
+	if action == ActionCreate {
+		pk := reflect.ValueOf(e).Elem().FieldByName(h.pkField)
+		pk.Set(reflect.Zero(pk.Type()))
+	}
+	if v := h.opts.Validate; v != nil {
+		if err = v(r, action, e); err != nil {
+			return e, nil, fmt.Errorf("%w: %v", search.ErrInvalidRequest, err)
+		}
+	}
+	return e, keys, nil
+}
+
+func (h *handler[E]) newEntity() E {
+	return reflect.New(reflect.TypeOf(h.entity).Elem()).Interface().(E)
+}
+
+// setPK set the primary key field of the entity parsed from the id, the zero id is not found
+func (h *handler[E]) setPK(e E, id string) error {
+	value := reflect.ValueOf(e).Elem().FieldByName(h.pkField)
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(id)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(id, 10, value.Type().Bits())
+		if err != nil {
+			return errNotFound
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(id, 10, value.Type().Bits())
+		if err != nil {
+			return errNotFound
+		}
+		value.SetUint(u)
+	default:
+		return errSinglePK(h.entity)
+	}
+	if value.IsZero() {
+		return errNotFound
+	}
+	return nil
+}
+
+// error write the error response, the internal errors are not exposed
+func (h *handler[E]) error(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, search.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, errNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, errMethodNotAllowed):
+		status = http.StatusMethodNotAllowed
+	default:
+		anorm.Logger.Error(nil, "crud: %v", err)
+		err = errInternal
+	}
+	h.write(w, status, &errorBody{err.Error()})
+}
+
+func (h *handler[E]) write(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
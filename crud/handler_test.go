@@ -0,0 +1,215 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-the-way/anorm"
+	"github.com/go-the-way/sg"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	ID      int    `orm:"pk{T} c{id}" json:"id"`
+	Name    string `orm:"c{name}" json:"name"`
+	Role    string `orm:"c{role}" json:"role"`
+	Created string `orm:"c{created} ug{T}" json:"created"`
+	Token   string `orm:"c{token}" json:"-"`
+}
+
+func (u *testUser) Configure(c *anorm.EC) {
+	c.Table = "crud_user"
+}
+
+func init() {
+	if tdb, err := sql.Open("mysql", os.Getenv("ANORM_TEST_DSN")+"?parseTime=true"); err != nil {
+		panic(err)
+	} else {
+		anorm.DataSourcePool.Push(tdb)
+	}
+	anorm.Register(new(testUser))
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return w
+}
+
+func TestHandlerPanic(t *testing.T) {
+	for _, writable := range [][]string{{"Password"}, {"Name", "id"}} {
+		func() {
+			defer func() {
+				if re := recover(); re == nil {
+					t.Fatalf("test failed! %v", writable)
+				}
+			}()
+			Handler(new(testUser), &Options[*testUser]{Writable: writable})
+		}()
+	}
+}
+
+func TestHandlerUpdateColumns(t *testing.T) {
+	h := Handler[*testUser](new(testUser), nil).(*handler[*testUser])
+	keys := map[string]struct{}{"name": {}, "id": {}, "created": {}}
+	if columns := h.getUpdateColumns(http.MethodPut, keys); !reflect.DeepEqual(columns, []string{"name", "role"}) {
+		t.Fatalf("test failed! %v", columns)
+	}
+	if columns := h.getUpdateColumns(http.MethodPatch, keys); !reflect.DeepEqual(columns, []string{"name"}) {
+		t.Fatalf("test failed! %v", columns)
+	}
+	// the json:"-" field never set, even by PUT
+	if op, err := h.updateOp(httptest.NewRequest(http.MethodPut, "/1", nil), h.getUpdateColumns(http.MethodPut, nil)); err != nil {
+		t.Fatalf("test failed! %v", err)
+	} else if sqlStr, _ := op.ToSQL(&testUser{ID: 1}); sqlStr != "UPDATE crud_user SET name = ?, role = ? WHERE ((id = ?))" {
+		t.Fatalf("test failed! %s", sqlStr)
+	}
+	h = Handler(new(testUser), &Options[*testUser]{Writable: []string{"role", "Token"}}).(*handler[*testUser])
+	if columns := h.getUpdateColumns(http.MethodPatch, keys); len(columns) != 0 {
+		t.Fatalf("test failed! %v", columns)
+	}
+	if columns := h.getUpdateColumns(http.MethodPut, keys); !reflect.DeepEqual(columns, []string{"role"}) {
+		t.Fatalf("test failed! %v", columns)
+	}
+}
+
+func TestHandlerDecodeCreate(t *testing.T) {
+	h := Handler[*testUser](new(testUser), nil).(*handler[*testUser])
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id": 5, "name": "coco"}`))
+	if e, _, err := h.decode(r, ActionCreate); err != nil || e.ID != 0 || e.Name != "coco" {
+		t.Fatalf("test failed! %v %v", e, err)
+	}
+}
+
+func TestHandlerScope(t *testing.T) {
+	h := Handler(new(testUser), &Options[*testUser]{
+		Select: func(r *http.Request, op anorm.SelectOperation[*testUser]) anorm.SelectOperation[*testUser] {
+			return op.Where(sg.Eq("role", r.Header.Get("X-Role")))
+		},
+	}).(*handler[*testUser])
+	r := httptest.NewRequest(http.MethodPatch, "/1", nil)
+	r.Header.Set("X-Role", "user")
+	if op, err := h.updateOp(r, []string{"name"}); err != nil {
+		t.Fatalf("test failed! %v", err)
+	} else if sqlStr, ps := op.ToSQL(&testUser{ID: 1, Name: "coco"}); sqlStr != "UPDATE crud_user SET name = ? WHERE ((role = ?) AND (id = ?))" || !reflect.DeepEqual(ps, []any{"coco", "user", 1}) {
+		t.Fatalf("test failed! %s %v", sqlStr, ps)
+	}
+	if op, err := h.deleteOp(r); err != nil {
+		t.Fatalf("test failed! %v", err)
+	} else if sqlStr, ps := op.ToSQL(&testUser{ID: 1}); sqlStr != "DELETE FROM crud_user WHERE ((role = ?) AND (id = ?))" || !reflect.DeepEqual(ps, []any{"user", int64(1)}) {
+		t.Fatalf("test failed! %s %v", sqlStr, ps)
+	}
+}
+
+func TestHandlerRoute(t *testing.T) {
+	h := Handler[*testUser](new(testUser), nil)
+	for _, c := range []struct {
+		method, target string
+		status         int
+	}{
+		{http.MethodPut, "/", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/1", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/1/roles", http.StatusNotFound},
+		{http.MethodGet, "/x", http.StatusNotFound},
+		{http.MethodGet, "/0", http.StatusNotFound},
+		{http.MethodPost, "/", http.StatusBadRequest},
+		{http.MethodGet, "/?page=x", http.StatusBadRequest},
+		{http.MethodGet, "/?sort=password", http.StatusBadRequest},
+	} {
+		if w := serve(h, c.method, c.target, "{"); w.Code != c.status {
+			t.Fatalf("test failed! %s %s %d %s", c.method, c.target, w.Code, w.Body)
+		}
+	}
+}
+
+func TestHandlerHooks(t *testing.T) {
+	h := Handler(new(testUser), &Options[*testUser]{
+		Authorize: func(r *http.Request, action Action) error {
+			switch {
+			case r.Header.Get("Authorization") == "" && r.Method != http.MethodPost:
+				return ErrUnauthorized
+			case action == ActionDelete:
+				return errors.New("admin only")
+			}
+			return nil
+		},
+		Writable: []string{"Name"},
+		Validate: func(r *http.Request, action Action, e *testUser) error {
+			if e.Role != "" {
+				t.Fatal("test failed!")
+			}
+			if e.Name == "" {
+				return errors.New("name required")
+			}
+			return nil
+		},
+	})
+	if w := serve(h, http.MethodGet, "/1", ""); w.Code != http.StatusUnauthorized {
+		t.Fatal("test failed!")
+	}
+	r := httptest.NewRequest(http.MethodDelete, "/1", nil)
+	r.Header.Set("Authorization", "x")
+	w := httptest.NewRecorder()
+	if h.ServeHTTP(w, r); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "admin only") {
+		t.Fatal("test failed!")
+	}
+	if w := serve(h, http.MethodPost, "/", `{"role": "admin"}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "name required") {
+		t.Fatal("test failed!")
+	}
+}
+
+type testCtxKey struct{}
+
+func TestHandlerContext(t *testing.T) {
+	errAbort := errors.New("test abort")
+	names := make([]string, 0)
+	anorm.UseInterceptor(func(ctx context.Context, stmt *anorm.Statement, invoker anorm.Invoker) (any, time.Duration, error) {
+		if ctx.Value(testCtxKey{}) == nil {
+			return invoker(ctx, stmt)
+		}
+		names = append(names, stmt.Name)
+		return nil, 0, errAbort
+	})
+	h := Handler[*testUser](new(testUser), nil).(*handler[*testUser])
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "coco"}`))
+	r = r.WithContext(context.WithValue(r.Context(), testCtxKey{}, 1))
+	w := httptest.NewRecorder()
+	if h.ServeHTTP(w, r); w.Code != http.StatusInternalServerError {
+		t.Fatalf("test failed! %d", w.Code)
+	}
+	if _, err := h.selectOp(r).One(&testUser{ID: 1}); !errors.Is(err, errAbort) {
+		t.Fatalf("test failed! %v", err)
+	}
+	if op, err := h.updateOp(r, []string{"name"}); err != nil {
+		t.Fatalf("test failed! %v", err)
+	} else if _, err = op.UpByPK(&testUser{ID: 1, Name: "coco"}); !errors.Is(err, errAbort) {
+		t.Fatalf("test failed! %v", err)
+	}
+	if op, err := h.deleteOp(r); err != nil {
+		t.Fatalf("test failed! %v", err)
+	} else if _, err = op.Del(&testUser{ID: 1}); !errors.Is(err, errAbort) {
+		t.Fatalf("test failed! %v", err)
+	}
+	if len(names) != 4 {
+		t.Fatalf("test failed! %v", names)
+	}
+}
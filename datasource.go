@@ -15,17 +15,20 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/go-the-way/anorm/pagination"
 	"sync"
 )
 
 type dataSourcePool struct {
 	mu  *sync.Mutex
 	dbM map[string]*sql.DB
+	itM map[string][]Interceptor
+	dlM map[string]pagination.Dialect
 }
 
 var (
 	// DataSourcePool global datasource pool
-	DataSourcePool = &dataSourcePool{mu: &sync.Mutex{}, dbM: make(map[string]*sql.DB, 0)}
+	DataSourcePool = &dataSourcePool{mu: &sync.Mutex{}, dbM: make(map[string]*sql.DB, 0), itM: make(map[string][]Interceptor, 0), dlM: make(map[string]pagination.Dialect, 0)}
 )
 
 // Push master datasource
@@ -49,3 +52,33 @@ func (p *dataSourcePool) Required(name string) *sql.DB {
 	}
 	return db
 }
+
+// UseInterceptor append name datasource interceptors
+func (p *dataSourcePool) UseInterceptor(name string, its ...Interceptor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.itM[name] = append(p.itM[name], its...)
+}
+
+func (p *dataSourcePool) getInterceptors(name string) []Interceptor {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.itM[name]
+}
+
+// SetDialect set name datasource dialect
+func (p *dataSourcePool) SetDialect(name string, dialect pagination.Dialect) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dlM[name] = dialect
+}
+
+// Dialect return name datasource dialect, default pagination.MySql
+func (p *dataSourcePool) Dialect(name string) pagination.Dialect {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if dialect, have := p.dlM[name]; have {
+		return dialect
+	}
+	return pagination.MySql
+}
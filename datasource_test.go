@@ -12,6 +12,7 @@
 package anorm
 
 import (
+	"github.com/go-the-way/anorm/pagination"
 	"reflect"
 	"testing"
 )
@@ -41,3 +42,13 @@ func TestDSRequired(t *testing.T) {
 	}()
 	DataSourcePool.Required("hello")
 }
+
+func TestDSDialect(t *testing.T) {
+	if DataSourcePool.Dialect("TestDSDialect") != pagination.MySql {
+		t.Fatal("call Dialect, expect default MySql dialect")
+	}
+	DataSourcePool.SetDialect("TestDSDialect", pagination.Pg)
+	if DataSourcePool.Dialect("TestDSDialect") != pagination.Pg {
+		t.Fatal("call SetDialect, expect Pg dialect")
+	}
+}
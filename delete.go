@@ -12,6 +12,7 @@
 package anorm
 
 import (
+	"context"
 	"database/sql"
 	"github.com/go-the-way/sg"
 )
@@ -19,15 +20,20 @@ import (
 type (
 	DeleteOperation[E Entity] interface {
 		BeginTx(txm *TxManager, options ...*sql.TxOptions) error
+		WithContext(ctx context.Context) DeleteOperation[E]
 		IfWhere(cond bool, wheres ...sg.Ge) DeleteOperation[E]
 		IfOnlyWhere(cond bool, wheres ...sg.Ge) DeleteOperation[E]
 		Where(wheres ...sg.Ge) DeleteOperation[E]
 		OnlyWhere(wheres ...sg.Ge) DeleteOperation[E]
+		MatchFields(fields ...string) DeleteOperation[E]
 		Del(e E) (count int64, err error)
+		ToSQL(e E) (sqlStr string, ps []any)
 	}
 	deleteOperation[E Entity] struct {
 		orm                *Orm[E]
 		wheres, onlyWheres []sg.Ge
+		matchFields        map[string]struct{}
+		err                error // the first builder error, returned by Del
 	}
 )
 
@@ -47,6 +53,12 @@ func (o *deleteOperation[E]) BeginTx(txm *TxManager, options ...*sql.TxOptions)
 	return o.orm.BeginTx(txm, options...)
 }
 
+// WithContext set the context passed to the entity hooks and the interceptors
+func (o *deleteOperation[E]) WithContext(ctx context.Context) DeleteOperation[E] {
+	o.orm.WithContext(ctx)
+	return o
+}
+
 // IfWhere if cond is true, append wheres
 func (o *deleteOperation[E]) IfWhere(cond bool, wheres ...sg.Ge) DeleteOperation[E] {
 	if cond {
@@ -75,8 +87,23 @@ func (o *deleteOperation[E]) OnlyWhere(wheres ...sg.Ge) DeleteOperation[E] {
 	return o
 }
 
-func (o *deleteOperation[E]) appendWhereGes(entity E) {
-	o.wheres = append(o.wheres, o.orm.getWhereGes(entity)...)
+// MatchFields match the given fields even when they hold zero values, accepts field or column names,
+// a nil pointer field matches IS NULL
+//
+// The unknown fields fail Del with an error
+func (o *deleteOperation[E]) MatchFields(fields ...string) DeleteOperation[E] {
+	matchFields, err := o.orm.getMatchFields(o.matchFields, fields...)
+	o.matchFields = matchFields
+	if o.err == nil {
+		o.err = err
+	}
+	return o
+}
+
+func (o *deleteOperation[E]) getWheres(entity E) []sg.Ge {
+	wheres := make([]sg.Ge, 0, len(o.wheres))
+	wheres = append(wheres, o.wheres...)
+	return append(wheres, o.orm.getWhereGes(entity, o.matchFields)...)
 }
 
 func (o *deleteOperation[E]) getDeleteBuilder(entity E) (string, []any) {
@@ -84,12 +111,20 @@ func (o *deleteOperation[E]) getDeleteBuilder(entity E) (string, []any) {
 	if len(o.onlyWheres) > 0 {
 		builder.Where(sg.AndGroup(o.onlyWheres...))
 	} else {
-		o.appendWhereGes(entity)
-		builder.Where(sg.AndGroup(o.wheres...))
+		builder.Where(sg.AndGroup(o.getWheres(entity)...))
 	}
 	return builder.Build()
 }
 
+// ToSQL return the SQL and arguments Del would execute, without touching the datasource
+func (o *deleteOperation[E]) ToSQL(e E) (sqlStr string, ps []any) {
+	if o.err != nil {
+		return "", nil
+	}
+	sqlStr, ps = o.getDeleteBuilder(e)
+	return sqlStr, unmaskArgs(ps)
+}
+
 // Del delete entities
 //
 // Params:
@@ -107,18 +142,21 @@ func (o *deleteOperation[E]) Del(e E) (int64, error) {
 		result sql.Result
 		err    error
 	)
-	sqlStr, ps := o.getDeleteBuilder(e)
-	queryLog("OpsForDelete.Del", sqlStr, ps)
-	if o.orm.openTx {
-		result, err = o.orm.tx.Exec(sqlStr, ps...)
-	} else {
-		result, err = o.orm.db.Exec(sqlStr, ps...)
+	if o.err != nil {
+		return 0, o.err
+	}
+	if err = o.orm.beforeDelete(e); err != nil {
+		return 0, err
 	}
-	queryErrorLog(err, "OpsForDelete.Del", sqlStr, ps)
+	sqlStr, ps := o.getDeleteBuilder(e)
+	result, err = o.orm.exec("OpsForDelete.Del", sqlStr, ps)
 	ra := int64(0)
 	if result != nil {
 		ra, err = result.RowsAffected()
-		queryErrorLog(err, "OpsForDelete.Del", sqlStr, ps)
+		queryErrorLog(err, "OpsForDelete.Del", sqlStr, logArgs(ps))
+	}
+	if err != nil {
+		return ra, err
 	}
-	return ra, err
+	return ra, o.orm.afterDelete(e)
 }
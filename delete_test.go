@@ -12,6 +12,8 @@
 package anorm
 
 import (
+	"github.com/go-the-way/sg"
+	"reflect"
 	"testing"
 )
 
@@ -153,3 +155,18 @@ func TestDeleteBeginTx(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestDeleteToSQL(t *testing.T) {
+	{
+		sqlStr, ps := Delete(new(userEntity)).Where(sg.Eq("age", 9)).ToSQL(&userEntity{ID: 1})
+		if sqlStr != "DELETE FROM user_entity WHERE ((age = ?) AND (id = ?))" || !reflect.DeepEqual(ps, []any{9, int64(1)}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, ps := Delete(new(userEntity)).OnlyWhere(sg.Eq("age", 9)).ToSQL(&userEntity{ID: 1})
+		if sqlStr != "DELETE FROM user_entity WHERE ((age = ?))" || !reflect.DeepEqual(ps, []any{9}) {
+			t.Fatal("test failed!")
+		}
+	}
+}
@@ -34,6 +34,7 @@ var (
 	entityColumnFieldMap   = make(map[string]map[string]string)               // K<entityPKGName> V< K<Column> V<Field> >
 	entityInsertIgnoreMap  = make(map[string]map[string]struct{})             // K<entityPKGName> V< K<Column> V<> >
 	entityUpdateIgnoreMap  = make(map[string]map[string]struct{})             // K<entityPKGName> V< K<Column> V<> >
+	entitySensitiveMap     = make(map[string]map[string]struct{})             // K<entityPKGName> V< K<Column> V<> >
 	entityNullFieldMap     = make(map[string]map[string]*NullField)           // K<entityPKGName> V< K<Field> V<*NullField> >
 	entityJoinRefMap       = make(map[string]map[string]*JoinRef)             // K<entityPKGName> V< K<Field> V<*JoinRef> >
 	entityJoinNullFieldMap = make(map[string]map[string]*NullField)           // K<entityPKGName> V< K<Field> V<*NullField> >
@@ -81,6 +82,8 @@ type (
 		InsertIgnores []sg.C
 		// UpdateIgnores defines Table update ignore columns
 		UpdateIgnores []sg.C
+		// SensitiveColumns defines Table sensitive columns, their arguments masked in the logs
+		SensitiveColumns []sg.C
 		// JoinRefs defines Table join rel table
 		JoinRefs map[string]*JoinRef
 		// JoinNullFields defines Table null fields when joined
@@ -96,6 +99,7 @@ type (
 		Column       string `alias:"c"`
 		InsertIgnore bool   `alias:"ig"`
 		UpdateIgnore bool   `alias:"ug"`
+		Sensitive    bool   `alias:"sensitive"`
 		Definition   string `alias:"def"`
 		Join         string `alias:"join"` // join{type,self_column,join_table,join_column}
 	}
@@ -112,7 +116,7 @@ type (
 )
 
 func (t *tag) String() string {
-	return fmt.Sprintf("PK:%v, Column:%s, InsertIgnore:%v, UpdateIgnore:%v, Definition:%s, Join:%s", t.PK, t.Column, t.InsertIgnore, t.UpdateIgnore, t.Definition, t.Join)
+	return fmt.Sprintf("PK:%v, Column:%s, InsertIgnore:%v, UpdateIgnore:%v, Sensitive:%v, Definition:%s, Join:%s", t.PK, t.Column, t.InsertIgnore, t.UpdateIgnore, t.Sensitive, t.Definition, t.Join)
 }
 
 // Register defines register a EntityConfigurator struct for anorm
@@ -126,6 +130,7 @@ func Register(entity Entity) {
 
 	insertIgnoreMap := make(map[string]struct{}, 0)
 	updateIgnoreMap := make(map[string]struct{}, 0)
+	sensitiveMap := make(map[string]struct{}, 0)
 	joinRefMap := make(map[string]*JoinRef, 0)
 	joinNullFieldMap := make(map[string]*NullField, 0)
 	nullFieldMap := make(map[string]*NullField, 0)
@@ -155,6 +160,7 @@ func Register(entity Entity) {
 			columnDefinition string
 			insertIgnore     bool
 			updateIgnore     bool
+			sensitive        bool
 			pk               bool
 			join             string
 		)
@@ -169,6 +175,7 @@ func Register(entity Entity) {
 			columnDefinition = curTag.Definition
 			insertIgnore = curTag.InsertIgnore
 			updateIgnore = curTag.UpdateIgnore
+			sensitive = curTag.Sensitive
 			pk = curTag.PK
 			join = curTag.Join
 			Logger.Debug([]*logField{LogField("entity", reflect.TypeOf(entity)), LogField("tag", curTag.String())}, "parsed")
@@ -189,6 +196,9 @@ func Register(entity Entity) {
 			if updateIgnore {
 				updateIgnoreMap[column] = struct{}{}
 			}
+			if sensitive {
+				sensitiveMap[column] = struct{}{}
+			}
 			setJoinMap(entity, fieldName, curTag, join, joinRefMap)
 
 			fields = append(fields, fieldName)
@@ -223,6 +233,12 @@ func Register(entity Entity) {
 		}
 	}
 
+	if vs := c.SensitiveColumns; vs != nil {
+		for _, v := range vs {
+			sensitiveMap[string(v)] = struct{}{}
+		}
+	}
+
 	if vs := c.JoinRefs; vs != nil {
 		for k, v := range vs {
 			joinRefMap[k] = v
@@ -256,6 +272,7 @@ func Register(entity Entity) {
 	entityColumnFieldMap[entityPkgName] = columnFieldMap
 	entityInsertIgnoreMap[entityPkgName] = insertIgnoreMap
 	entityUpdateIgnoreMap[entityPkgName] = updateIgnoreMap
+	entitySensitiveMap[entityPkgName] = sensitiveMap
 	entityJoinRefMap[entityPkgName] = joinRefMap
 	entityJoinNullFieldMap[entityPkgName] = joinNullFieldMap
 	entityNullFieldMap[entityPkgName] = nullFieldMap
@@ -279,6 +296,47 @@ func Register(entity Entity) {
 	migrate(entity, c, pkGes, columnGes)
 }
 
+// PKFields return the primary key field names of the registered entity
+func PKFields(entity Entity) []string {
+	entityPkgName := getEntityPkgName(entity)
+	fields := make([]string, 0, len(entityPKMap[entityPkgName]))
+	for _, pk := range entityPKMap[entityPkgName] {
+		fields = append(fields, entityColumnFieldMap[entityPkgName][pk])
+	}
+	return fields
+}
+
+// FieldColumn return the column of the registered entity field, accepts field or column names
+func FieldColumn(entity Entity, field string) (string, bool) {
+	entityPkgName := getEntityPkgName(entity)
+	if column, have := entityFieldColumnMap[entityPkgName][field]; have {
+		return column, true
+	}
+	if _, have := entityColumnFieldMap[entityPkgName][field]; have {
+		return field, true
+	}
+	return "", false
+}
+
+// UpdateFields return the field names UpByPK sets by default, the primary keys and the ug{T} fields excluded
+func UpdateFields(entity Entity) []string {
+	entityPkgName := getEntityPkgName(entity)
+	pkMap := make(map[string]struct{}, len(entityPKMap[entityPkgName]))
+	for _, pk := range entityPKMap[entityPkgName] {
+		pkMap[pk] = struct{}{}
+	}
+	fields := make([]string, 0)
+	for _, f := range entityFieldMap[entityPkgName] {
+		column := entityFieldColumnMap[entityPkgName][f]
+		_, pk := pkMap[column]
+		_, ignored := entityUpdateIgnoreMap[entityPkgName][column]
+		if !pk && !ignored {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
 func setJoinMap(entity Entity, fieldName string, curTag *tag, join string, joinRefMap map[string]*JoinRef) {
 	if join == "" {
 		return
@@ -13,6 +13,7 @@ package anorm
 
 import (
 	"github.com/go-the-way/sg"
+	"reflect"
 	"testing"
 )
 
@@ -80,3 +81,24 @@ func TestEntity2(t *testing.T) {
 	Logger.SetLogLevel(LogLevelDebug)
 	Register(new(_ErrorEntity))
 }
+
+func TestPKFieldsFieldColumn(t *testing.T) {
+	if _, have := entityTableMap[getEntityPkgName(new(_SortMaster))]; !have {
+		Register(new(_SortMaster))
+	}
+	if fields := PKFields(new(_SortMaster)); len(fields) != 1 || fields[0] != "ID" {
+		t.Fatal("test failed!")
+	}
+	if column, have := FieldColumn(new(_SortMaster), "RelID"); !have || column != "rel_id" {
+		t.Fatal("test failed!")
+	}
+	if column, have := FieldColumn(new(_SortMaster), "rel_id"); !have || column != "rel_id" {
+		t.Fatal("test failed!")
+	}
+	if _, have := FieldColumn(new(_SortMaster), "Unknown"); have {
+		t.Fatal("test failed!")
+	}
+	if fields := UpdateFields(new(userEntity)); !reflect.DeepEqual(fields, []string{"Name", "Age", "Address", "Phone"}) {
+		t.Fatalf("test failed! %v", fields)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Plan defines the plan returned by the dialect's EXPLAIN
+type Plan struct {
+	// Columns defines the plan columns
+	Columns []string
+	// Rows defines the plan rows keyed by the column
+	Rows []map[string]any
+	// FullScan defines any of the rows is a full table scan
+	FullScan bool
+}
+
+var (
+	errExplainNotSupported = func(ds string) error {
+		return errors.New(fmt.Sprintf("anorm: the dialect of named[%s] data source not supported explain", ds))
+	}
+
+	// ExplainFullScanWarn defines log a warning when the plan contains a full table scan, enable it in development
+	ExplainFullScanWarn = false
+)
+
+// ExplainStatement run the datasource dialect's EXPLAIN on the Statement through the interceptors
+//
+// The Statement name is suffixed with .Explain, see DataSourcePool.SetDialect
+func ExplainStatement(ctx context.Context, runner Runner, stmt *Statement) (*Plan, error) {
+	dialect := DataSourcePool.Dialect(stmt.DS)
+	explainSQL := dialect.Explain(stmt.SQL)
+	if explainSQL == "" {
+		return nil, errExplainNotSupported(stmt.DS)
+	}
+	rows, err := QueryStatement(ctx, runner, &Statement{Name: stmt.Name + ".Explain", SQL: explainSQL, Args: stmt.Args, DS: stmt.DS})
+	if err != nil {
+		return nil, err
+	}
+	plan, err := scanPlan(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range plan.Rows {
+		if dialect.FullScan(row) {
+			plan.FullScan = true
+			break
+		}
+	}
+	if plan.FullScan && ExplainFullScanWarn {
+		Logger.Error([]*logField{LogField("Name", stmt.Name), LogField("SQL", stmt.SQL), LogField("Plan", plan.Rows)}, "warn: full table scan")
+	}
+	return plan, nil
+}
+
+func scanPlan(rows *sql.Rows) (*Plan, error) {
+	columns, ms, err := scanMaps(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &Plan{Columns: columns, Rows: ms}, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"github.com/go-the-way/anorm/pagination"
+	"testing"
+)
+
+func TestExplainStatementNotSupported(t *testing.T) {
+	DataSourcePool.SetDialect("TestExplainStatementNotSupported", pagination.SqlServer("id asc"))
+	r := &testRunner{}
+	if _, err := ExplainStatement(context.Background(), r, &Statement{Name: "Test.Query", SQL: "SELECT 1", DS: "TestExplainStatementNotSupported"}); err == nil {
+		t.Fatal("TestExplainStatementNotSupported failed!")
+	}
+	if r.sqlStr != "" {
+		t.Fatal("TestExplainStatementNotSupported failed!")
+	}
+}
+
+func TestExplainStatement(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	plan, err := ExplainStatement(context.Background(), testDB, &Statement{Name: "Test.Query", SQL: "SELECT * FROM user_entity WHERE name = ?", Args: []any{"coco"}, DS: "_"})
+	if err != nil {
+		t.Fatalf("TestExplainStatement failed: %v\n", err)
+	}
+	if len(plan.Columns) <= 0 || len(plan.Rows) != 1 || !plan.FullScan {
+		t.Fatal("TestExplainStatement failed!")
+	}
+	if plan.Rows[0]["table"] != "user_entity" {
+		t.Fatal("TestExplainStatement failed!")
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-the-way/sg"
+)
+
+// FilterTag defines the struct tag the filter conditions declared by, e.g. `q:"like,name"`, `q:"-"` skips the field
+//
+// The tag is `op[,column]`, the column defaults to the underline name of the field, Filter accepts the entity field names too, the ops:
+//
+// - eq, ne, gt, gte, lt, lte: compare the column with the value
+//
+// - like, prefix, suffix: match the column contains, starts with or ends with the value, wildcards escaped
+//
+// - in: match the column in the slice or the comma separated string
+//
+// - null: bool value, true IS NULL, false IS NOT NULL. A false bool is skipped as empty, use *bool to filter IS NOT NULL
+var FilterTag = "q"
+
+var (
+	// filterFieldsCache caches the filter fields of the struct type, K<reflect.Type> V<[]filterField>
+	filterFieldsCache = &sync.Map{}
+	likeEscaper       = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	errFilterDto      = func(dto any) error {
+		return errors.New(fmt.Sprintf("anorm: filter must be a struct or a pointer to struct, got %T", dto))
+	}
+	errFilterOp = func(op string, sf reflect.StructField) error {
+		return errors.New(fmt.Sprintf("anorm: filter unsupported op[%s] of field [%s]", op, sf.Name))
+	}
+	errFilterNotJoined = func(field string) error {
+		return errors.New(fmt.Sprintf("anorm: filter join field [%s] requires Join called before", field))
+	}
+)
+
+// filterField defines the parsed filter field
+type filterField struct {
+	path   []int
+	op     string
+	column string
+}
+
+// Filter append the wheres declared by the FilterTag of dto fields, the empty fields skipped
+//
+// The filter columns resolved by the entity fields or columns, qualified as the Where columns of the entity or the joined tables,
+// the values of the sensitive columns marked sensitive
+//
+// The dto not a struct, declaring an unsupported op, an unknown column or a join field without Join called before
+// fails the executions with an error, see FilterWheres for the entity-agnostic wheres
+func (o *selectOperation[E]) Filter(dto any) SelectOperation[E] {
+	wheres, err := filterWheres(dto, o.getFilterColumn)
+	if err != nil {
+		o.setErr(err)
+		return o
+	}
+	return o.Where(wheres...)
+}
+
+// getFilterColumn return the qualified column of the filter column and the value marks the sensitive values
+func (o *selectOperation[E]) getFilterColumn(name string) (string, func(val any) any, error) {
+	entityPkgName := getEntityPkgName(o.orm.entity)
+	fieldName := o.getFieldName(name)
+	column, have := o.getFieldColumn(name)
+	if !have {
+		if _, join := entityJoinRefMap[entityPkgName][fieldName]; join {
+			return "", nil, errFilterNotJoined(name)
+		}
+		return "", nil, errUnknownField(o.orm.entity, name)
+	}
+	entityColumn := entityFieldColumnMap[entityPkgName][fieldName]
+	return string(column), func(val any) any { return sensitiveValue(entityPkgName, entityColumn, val) }, nil
+}
+
+// FilterWheres return the wheres declared by the FilterTag of dto fields, the columns used as they are
+//
+// The nil pointers, zero values, empty slices and blank strings skipped, the non-nil pointers always included
+func FilterWheres(dto any) ([]sg.Ge, error) {
+	return filterWheres(dto, nil)
+}
+
+// filterWheres return the wheres of dto fields, the resolve maps the filter column to the where column and the value, nil keeps them
+func filterWheres(dto any, resolve func(name string) (string, func(val any) any, error)) ([]sg.Ge, error) {
+	rv := reflect.ValueOf(dto)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errFilterDto(dto)
+	}
+	fields, err := getFilterFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	wheres := make([]sg.Ge, 0, len(fields))
+	for _, f := range fields {
+		value, ok := getFilterValue(rv, f.path)
+		if !ok {
+			continue
+		}
+		column, mark := f.column, func(val any) any { return val }
+		if resolve != nil {
+			if column, mark, err = resolve(f.column); err != nil {
+				return nil, err
+			}
+		}
+		if ge := f.where(column, value, mark); ge != nil {
+			wheres = append(wheres, ge)
+		}
+	}
+	return wheres, nil
+}
+
+func getFilterFields(rt reflect.Type) ([]filterField, error) {
+	if v, have := filterFieldsCache.Load(rt); have {
+		return v.([]filterField), nil
+	}
+	fields := make([]filterField, 0)
+	var walk func(rt reflect.Type, index []int) error
+	walk = func(rt reflect.Type, index []int) error {
+		for i := 0; i < rt.NumField(); i++ {
+			sf := rt.Field(i)
+			path := append(append(make([]int, 0, len(index)+1), index...), i)
+			tag, tagged := sf.Tag.Lookup(FilterTag)
+			if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+				if err := walk(sf.Type, path); err != nil {
+					return err
+				}
+				continue
+			}
+			if !tagged || tag == "-" || !sf.IsExported() {
+				continue
+			}
+			op, column, _ := strings.Cut(tag, ",")
+			op, column = strings.TrimSpace(op), strings.TrimSpace(column)
+			switch op {
+			case "eq", "ne", "gt", "gte", "lt", "lte", "like", "prefix", "suffix", "in", "null":
+			default:
+				return errFilterOp(op, sf)
+			}
+			if column == "" {
+				column = getStrategyName(sf.Name, Underline)
+			}
+			fields = append(fields, filterField{path, op, column})
+		}
+		return nil
+	}
+	if err := walk(rt, nil); err != nil {
+		return nil, err
+	}
+	filterFieldsCache.Store(rt, fields)
+	return fields, nil
+}
+
+// getFilterValue return the value of the field path, false if the value is empty
+func getFilterValue(rv reflect.Value, path []int) (reflect.Value, bool) {
+	value := rv.FieldByIndex(path)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return value, false
+		}
+		return value.Elem(), true
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value, value.Len() > 0
+	case reflect.String:
+		return value, strings.TrimSpace(value.String()) != ""
+	}
+	return value, !value.IsZero()
+}
+
+func (f filterField) where(column string, value reflect.Value, mark func(val any) any) sg.Ge {
+	c := sg.C(column)
+	switch f.op {
+	case "ne":
+		return sg.NotEq(c, mark(value.Interface()))
+	case "gt":
+		return sg.Gt(c, mark(value.Interface()))
+	case "gte":
+		return sg.GtEq(c, mark(value.Interface()))
+	case "lt":
+		return sg.Lt(c, mark(value.Interface()))
+	case "lte":
+		return sg.LtEq(c, mark(value.Interface()))
+	case "like":
+		return sg.Like(c, mark(likeEscaper.Replace(fmt.Sprint(value.Interface()))))
+	case "prefix":
+		return sg.RightLike(c, mark(likeEscaper.Replace(fmt.Sprint(value.Interface()))))
+	case "suffix":
+		return sg.LeftLike(c, mark(likeEscaper.Replace(fmt.Sprint(value.Interface()))))
+	case "in":
+		vs := getFilterInValues(value)
+		if len(vs) <= 0 {
+			return nil
+		}
+		for i := range vs {
+			vs[i] = mark(vs[i])
+		}
+		return sg.In(c, vs...)
+	case "null":
+		if value.Kind() == reflect.Bool && value.Bool() {
+			return newFuncGe(fmt.Sprintf("(%s IS NULL)", column))
+		}
+		return newFuncGe(fmt.Sprintf("(%s IS NOT NULL)", column))
+	}
+	return sg.Eq(c, mark(value.Interface()))
+}
+
+// getFilterInValues return the elements of the slice, or the parts of the comma separated string
+func getFilterInValues(value reflect.Value) []any {
+	vs := make([]any, 0)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			vs = append(vs, value.Index(i).Interface())
+		}
+	case reflect.String:
+		for _, s := range strings.Split(value.String(), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				vs = append(vs, s)
+			}
+		}
+	default:
+		vs = append(vs, value.Interface())
+	}
+	return vs
+}
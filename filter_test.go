@@ -0,0 +1,155 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"github.com/go-the-way/anorm/pagination"
+	"reflect"
+	"testing"
+)
+
+type (
+	userPage struct {
+		Page int
+	}
+	userFilter struct {
+		userPage
+		Name     string   `q:"like,name"`
+		Prefix   string   `q:"prefix,address"`
+		MinAge   int      `q:"gte,age"`
+		Age      *int     `q:"eq"`
+		Phones   []string `q:"in,phone"`
+		IDs      string   `q:"in,id"`
+		NoPhone  bool     `q:"null,phone"`
+		NoAddr   *bool    `q:"null,address"`
+		Internal string   `q:"-"`
+	}
+)
+
+func TestFilterWheres(t *testing.T) {
+	{
+		if _, err := FilterWheres(1); err == nil {
+			t.Fatal("test failed!")
+		}
+		if _, err := FilterWheres(&struct {
+			Name string `q:"unknown"`
+		}{}); err == nil {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		wheres, err := FilterWheres(&userFilter{Internal: "x"})
+		if err != nil || len(wheres) != 0 {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, ps := Select(new(userEntity)).Columns("ID").Filter(&userFilter{Name: "50%_a", Age: new(int), Phones: []string{"1", "2"}, IDs: "3, 4,"}).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t WHERE ((t.name LIKE CONCAT('%', ?, '%')) AND (t.age = ?) AND (t.phone IN (?, ?)) AND (t.id IN (?, ?)))" ||
+			!reflect.DeepEqual(ps, []any{`50\%\_a`, 0, "1", "2", "3", "4"}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		sqlStr, ps := Select(new(userEntity)).Columns("ID").Filter(userFilter{Prefix: "bei", MinAge: 9, NoPhone: true}).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t WHERE ((t.address LIKE CONCAT('', ?, '%')) AND (t.age >= ?) AND (t.phone IS NULL))" ||
+			!reflect.DeepEqual(ps, []any{"bei", 9}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		// a false bool skipped, a false *bool IS NOT NULL
+		noAddr := false
+		sqlStr, ps := Select(new(userEntity)).Columns("ID").Filter(&userFilter{NoPhone: false, NoAddr: &noAddr}).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t WHERE ((t.address IS NOT NULL))" || len(ps) != 0 {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		op := Select(new(userEntity)).Filter(1)
+		if sqlStr, _ := op.ToSQL(nil); sqlStr != "" {
+			t.Fatal("test failed!")
+		}
+		if _, err := op.List(nil); err == nil || err.Error() != "anorm: filter must be a struct or a pointer to struct, got int" {
+			t.Fatalf("test failed! %v", err)
+		}
+		if _, _, err := op.Page(nil, pagination.MySql, 0, 10); err == nil {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		// FilterWheres keeps the columns as they are
+		wheres, err := FilterWheres(&userFilter{Name: "co"})
+		if err != nil || len(wheres) != 1 {
+			t.Fatal("test failed!")
+		}
+		if sqlStr, _ := wheres[0].SQL(); sqlStr != "(name LIKE CONCAT('%', ?, '%'))" {
+			t.Fatalf("test failed! %s", sqlStr)
+		}
+	}
+}
+
+func TestSelectFilterResolve(t *testing.T) {
+	if _, have := entityTableMap[getEntityPkgName(new(_SelectiveJoin))]; !have {
+		Register(new(_SelectiveJoin))
+	}
+	{
+		// the sensitive column values marked sensitive
+		op := Select(new(testRedactE)).Columns("ID").Filter(&struct {
+			Name     string   `q:"eq"`
+			Password string   `q:"eq,Password"`
+			Tokens   []string `q:"in,token"`
+		}{"coco", "123456", []string{"a", "b"}}).(*selectOperation[*testRedactE])
+		if sqlStr, ps := op.getListBuilder(nil); sqlStr != "SELECT t.id AS ID FROM testRedactE AS t WHERE ((t.name = ?) AND (t.password = ?) AND (t.token IN (?, ?)))" ||
+			!reflect.DeepEqual(unmaskArgs(ps), []any{"coco", "123456", "a", "b"}) ||
+			!reflect.DeepEqual(logArgs(ps), []any{"coco", "******", "******", "******"}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		type relFilter struct {
+			RelName string `q:"eq,RelName"`
+		}
+		sqlStr, ps := Select(new(_SelectiveJoin)).Columns("ID").Join("RelName").Filter(relFilter{"rel"}).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM selective_join AS t LEFT JOIN join_rel AS rel2 ON rel2.id = t.rel_id WHERE ((rel2.name = ?))" ||
+			!reflect.DeepEqual(ps, []any{"rel"}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+		for _, tc := range []struct {
+			op  SelectOperation[*_SelectiveJoin]
+			err string
+		}{
+			{Select(new(_SelectiveJoin)).Filter(relFilter{"rel"}), "anorm: filter join field [RelName] requires Join called before"},
+			{Select(new(_SelectiveJoin)).Filter(&struct {
+				Typo string `q:"eq"`
+			}{"x"}), "anorm: unknown field [typo] of entity [*anorm._SelectiveJoin]"},
+		} {
+			if sqlStr, _ := tc.op.ToSQL(nil); sqlStr != "" {
+				t.Fatal("test failed!")
+			}
+			if _, err := tc.op.List(nil); err == nil || err.Error() != tc.err {
+				t.Fatalf("test failed! %v", err)
+			}
+		}
+	}
+}
+
+func TestSelectFilter(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	_ = insertUserEntity("co_co", testAge, testAddress, testPhone)
+	if es, err := Select(new(userEntity)).Filter(&userFilter{Name: "_", MinAge: testAge}).List(nil); err != nil {
+		t.Fatalf("TestSelectFilter failed: %v\n", err)
+	} else if len(es) != 1 || es[0].Name != "co_co" {
+		t.Fatal("TestSelectFilter failed!")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Entity lifecycle hooks
+//
+// An entity implements any of the hooks below to be called by the operations,
+// the tx is nil when the Orm has not begun a tx.
+//
+// A non-nil error returned by a Before hook aborts the operation before the SQL executed,
+// a non-nil error returned by an After hook is returned by the operation.
+type (
+	// BeforeInsertHook called before entity inserted
+	BeforeInsertHook interface {
+		BeforeInsert(ctx context.Context, tx *sql.Tx) error
+	}
+	// AfterInsertHook called after entity inserted
+	AfterInsertHook interface {
+		AfterInsert(ctx context.Context, tx *sql.Tx) error
+	}
+	// BeforeUpdateHook called before entity updated
+	BeforeUpdateHook interface {
+		BeforeUpdate(ctx context.Context, tx *sql.Tx) error
+	}
+	// AfterUpdateHook called after entity updated
+	AfterUpdateHook interface {
+		AfterUpdate(ctx context.Context, tx *sql.Tx) error
+	}
+	// BeforeDeleteHook called before entity deleted
+	BeforeDeleteHook interface {
+		BeforeDelete(ctx context.Context, tx *sql.Tx) error
+	}
+	// AfterDeleteHook called after entity deleted
+	AfterDeleteHook interface {
+		AfterDelete(ctx context.Context, tx *sql.Tx) error
+	}
+	// AfterFindHook called after each entity scanned
+	AfterFindHook interface {
+		AfterFind(ctx context.Context, tx *sql.Tx) error
+	}
+)
+
+func (o *Orm[E]) beforeInsert(e E) error {
+	if h, ok := any(e).(BeforeInsertHook); ok && entityNotNil(e) {
+		return h.BeforeInsert(o.ctx, o.tx)
+	}
+	return nil
+}
+
+func (o *Orm[E]) afterInsert(e E) error {
+	if h, ok := any(e).(AfterInsertHook); ok && entityNotNil(e) {
+		return h.AfterInsert(o.ctx, o.tx)
+	}
+	return nil
+}
+
+func (o *Orm[E]) beforeUpdate(e E) error {
+	if h, ok := any(e).(BeforeUpdateHook); ok && entityNotNil(e) {
+		return h.BeforeUpdate(o.ctx, o.tx)
+	}
+	return nil
+}
+
+func (o *Orm[E]) afterUpdate(e E) error {
+	if h, ok := any(e).(AfterUpdateHook); ok && entityNotNil(e) {
+		return h.AfterUpdate(o.ctx, o.tx)
+	}
+	return nil
+}
+
+func (o *Orm[E]) beforeDelete(e E) error {
+	if h, ok := any(e).(BeforeDeleteHook); ok && entityNotNil(e) {
+		return h.BeforeDelete(o.ctx, o.tx)
+	}
+	return nil
+}
+
+func (o *Orm[E]) afterDelete(e E) error {
+	if h, ok := any(e).(AfterDeleteHook); ok && entityNotNil(e) {
+		return h.AfterDelete(o.ctx, o.tx)
+	}
+	return nil
+}
+
+func (o *Orm[E]) afterFind(es []E) error {
+	for _, e := range es {
+		if h, ok := any(e).(AfterFindHook); ok && entityNotNil(e) {
+			if err := h.AfterFind(o.ctx, o.tx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
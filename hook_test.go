@@ -0,0 +1,134 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+var errTestHookAbort = errors.New("anorm: test hook abort")
+
+type testHookE struct {
+	ID    int    `orm:"pk{T} c{id} ig{T} def{id int not null auto_increment comment 'ID'}"`
+	Name  string `orm:"c{name} def{name varchar(20) not null comment 'Name'}"`
+	abort bool
+	calls []string
+	ctx   context.Context
+}
+
+func (t *testHookE) Configure(c *EC) {
+	c.Migrate = true
+	c.IFNotExists = true
+	c.Table = "test_hook_e"
+}
+
+func (t *testHookE) call(ctx context.Context, name string) error {
+	t.calls, t.ctx = append(t.calls, name), ctx
+	if t.abort {
+		return errTestHookAbort
+	}
+	return nil
+}
+
+func (t *testHookE) BeforeInsert(ctx context.Context, _ *sql.Tx) error {
+	return t.call(ctx, "BeforeInsert")
+}
+func (t *testHookE) AfterInsert(ctx context.Context, _ *sql.Tx) error {
+	return t.call(ctx, "AfterInsert")
+}
+func (t *testHookE) BeforeUpdate(ctx context.Context, _ *sql.Tx) error {
+	return t.call(ctx, "BeforeUpdate")
+}
+func (t *testHookE) AfterUpdate(ctx context.Context, _ *sql.Tx) error {
+	return t.call(ctx, "AfterUpdate")
+}
+func (t *testHookE) BeforeDelete(ctx context.Context, _ *sql.Tx) error {
+	return t.call(ctx, "BeforeDelete")
+}
+func (t *testHookE) AfterDelete(ctx context.Context, _ *sql.Tx) error {
+	return t.call(ctx, "AfterDelete")
+}
+func (t *testHookE) AfterFind(ctx context.Context, _ *sql.Tx) error { return t.call(ctx, "AfterFind") }
+
+func init() {
+	Register(new(testHookE))
+}
+
+func TestHookBeforeAbort(t *testing.T) {
+	{
+		e := &testHookE{Name: "hook", abort: true}
+		if err := Insert(new(testHookE)).One(e); !errors.Is(err, errTestHookAbort) {
+			t.Error("test failed")
+		}
+		if _, err := Insert(new(testHookE)).Batch(e); !errors.Is(err, errTestHookAbort) {
+			t.Error("test failed")
+		}
+		if _, err := Update(new(testHookE)).UpByPK(e); !errors.Is(err, errTestHookAbort) {
+			t.Error("test failed")
+		}
+		if _, err := Delete(new(testHookE)).Del(e); !errors.Is(err, errTestHookAbort) {
+			t.Error("test failed")
+		}
+		if len(e.calls) != 4 {
+			t.Error("test failed")
+		}
+	}
+}
+
+func TestHookContext(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	e := &testHookE{Name: "hook", abort: true}
+	_ = Insert(new(testHookE)).WithContext(ctx).One(e)
+	if e.ctx != ctx {
+		t.Fatal("test failed!")
+	}
+	_, _ = Update(new(testHookE)).WithContext(ctx).UpByPK(e)
+	_, _ = Delete(new(testHookE)).WithContext(ctx).Del(e)
+	if len(e.calls) != 3 || e.ctx != ctx {
+		t.Fatal("test failed!")
+	}
+}
+
+func TestHook(t *testing.T) {
+	_, _ = testDB.Exec("truncate table test_hook_e")
+	e := &testHookE{Name: "hook"}
+	if err := Insert(new(testHookE)).One(e); err != nil {
+		t.Fatalf("TestHook failed: %v\n", err)
+	}
+	if _, err := Update(new(testHookE)).UpByPK(e); err != nil {
+		t.Fatalf("TestHook failed: %v\n", err)
+	}
+	if _, err := Delete(new(testHookE)).Del(e); err != nil {
+		t.Fatalf("TestHook failed: %v\n", err)
+	}
+	expect := []string{"BeforeInsert", "AfterInsert", "BeforeUpdate", "AfterUpdate", "BeforeDelete", "AfterDelete"}
+	if len(e.calls) != len(expect) {
+		t.Fatal("TestHook failed!")
+	}
+	for i := range expect {
+		if e.calls[i] != expect[i] {
+			t.Fatal("TestHook failed!")
+		}
+	}
+	if err := Insert(new(testHookE)).One(&testHookE{Name: "hook"}); err != nil {
+		t.Fatalf("TestHook failed: %v\n", err)
+	}
+	if es, err := Select(new(testHookE)).List(nil); err != nil {
+		t.Fatalf("TestHook failed: %v\n", err)
+	} else if len(es) != 1 || len(es[0].calls) != 1 || es[0].calls[0] != "AfterFind" {
+		t.Fatal("TestHook failed!")
+	}
+}
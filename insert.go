@@ -12,6 +12,7 @@
 package anorm
 
 import (
+	"context"
 	"database/sql"
 	"reflect"
 
@@ -21,10 +22,13 @@ import (
 type (
 	InsertOperation[E Entity] interface {
 		BeginTx(txm *TxManager, options ...*sql.TxOptions) error
+		WithContext(ctx context.Context) InsertOperation[E]
 		Ignore(cs ...sg.C) InsertOperation[E]
 		One(e E) error
 		List(ignoreError bool, es ...E) error
 		Batch(es ...E) (count int64, err error)
+		ToSQL(e E) (sqlStr string, ps []any)
+		BatchToSQL(es ...E) (sqlStr string, ps []any)
 	}
 	insertOperation[E Entity] struct {
 		orm           *Orm[E]
@@ -48,6 +52,12 @@ func (o *insertOperation[E]) BeginTx(txm *TxManager, options ...*sql.TxOptions)
 	return o.orm.BeginTx(txm, options...)
 }
 
+// WithContext set the context passed to the entity hooks and the interceptors
+func (o *insertOperation[E]) WithContext(ctx context.Context) InsertOperation[E] {
+	o.orm.WithContext(ctx)
+	return o
+}
+
 // Ignore add ignore when inserts
 func (o *insertOperation[E]) Ignore(cs ...sg.C) InsertOperation[E] {
 	o.ignoreColumns = append(o.ignoreColumns, cs...)
@@ -55,7 +65,10 @@ func (o *insertOperation[E]) Ignore(cs ...sg.C) InsertOperation[E] {
 }
 
 func (o *insertOperation[E]) getIgnoreMap() map[string]struct{} {
-	ignoreMap := entityInsertIgnoreMap[getEntityPkgName(o.orm.entity)]
+	ignoreMap := make(map[string]struct{}, 0)
+	for c := range entityInsertIgnoreMap[getEntityPkgName(o.orm.entity)] {
+		ignoreMap[c] = struct{}{}
+	}
 	for _, c := range o.ignoreColumns {
 		ignoreMap[string(c)] = struct{}{}
 	}
@@ -78,7 +91,7 @@ func (o *insertOperation[E]) getInsertBuilder(es ...E) (string, []any) {
 				builder.Column(sg.C(fieldColumnMap[f]))
 			}
 			val := rt.FieldByName(f).Interface()
-			argGes = append(argGes, sg.Arg(val))
+			argGes = append(argGes, sg.Arg(sensitiveValue(getEntityPkgName(o.orm.entity), fieldColumnMap[f], val)))
 		}
 
 		if len(es) == 1 {
@@ -97,6 +110,21 @@ func (o *insertOperation[E]) getInsertBuilder(es ...E) (string, []any) {
 	return builder.Table(o.orm.table()).Build()
 }
 
+// ToSQL return the SQL and arguments One would execute, without touching the datasource
+func (o *insertOperation[E]) ToSQL(e E) (sqlStr string, ps []any) {
+	sqlStr, ps = o.getInsertBuilder(e)
+	return sqlStr, unmaskArgs(ps)
+}
+
+// BatchToSQL return the SQL and arguments Batch would execute, without touching the datasource
+func (o *insertOperation[E]) BatchToSQL(es ...E) (sqlStr string, ps []any) {
+	if len(es) <= 0 {
+		return "", nil
+	}
+	sqlStr, ps = o.getInsertBuilder(es...)
+	return sqlStr, unmaskArgs(ps)
+}
+
 // One exec insert one entity
 //
 // Params:
@@ -112,14 +140,11 @@ func (o *insertOperation[E]) One(e E) error {
 		result sql.Result
 		err    error
 	)
-	sqlStr, ps := o.getInsertBuilder(e)
-	queryLog("OpsForInsert.Count", sqlStr, ps)
-	if o.orm.openTx {
-		result, err = o.orm.tx.Exec(sqlStr, ps...)
-	} else {
-		result, err = o.orm.db.Exec(sqlStr, ps...)
+	if err = o.orm.beforeInsert(e); err != nil {
+		return err
 	}
-	queryErrorLog(err, "OpsForInsert.Count", sqlStr, ps)
+	sqlStr, ps := o.getInsertBuilder(e)
+	result, err = o.orm.exec("OpsForInsert.One", sqlStr, ps)
 	if err != nil {
 		return err
 	}
@@ -143,7 +168,7 @@ func (o *insertOperation[E]) One(e E) error {
 			}
 		}
 	}
-	return nil
+	return o.orm.afterInsert(e)
 }
 
 // List exec list entity, each entity call One(entity Entity) error
@@ -186,14 +211,13 @@ func (o *insertOperation[E]) Batch(entities ...E) (count int64, err error) {
 		return 0, nil
 	}
 	var result sql.Result
-	sqlStr, ps := o.getInsertBuilder(entities...)
-	queryLog("OpsForInsert.Batch", sqlStr, ps)
-	if o.orm.openTx {
-		result, err = o.orm.tx.Exec(sqlStr, ps...)
-	} else {
-		result, err = o.orm.db.Exec(sqlStr, ps...)
+	for _, e := range entities {
+		if err = o.orm.beforeInsert(e); err != nil {
+			return 0, err
+		}
 	}
-	queryErrorLog(err, "OpsForInsert.Batch", sqlStr, ps)
+	sqlStr, ps := o.getInsertBuilder(entities...)
+	result, err = o.orm.exec("OpsForInsert.Batch", sqlStr, ps)
 	if err != nil {
 		return 0, err
 	}
@@ -201,5 +225,10 @@ func (o *insertOperation[E]) Batch(entities ...E) (count int64, err error) {
 	if result != nil {
 		ra, _ = result.RowsAffected()
 	}
+	for _, e := range entities {
+		if err = o.orm.afterInsert(e); err != nil {
+			return ra, err
+		}
+	}
 	return ra, err
 }
@@ -13,6 +13,7 @@ package anorm
 
 import (
 	"github.com/go-the-way/sg"
+	"reflect"
 	"testing"
 )
 
@@ -238,3 +239,28 @@ func TestInsertBeginTx(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestInsertToSQL(t *testing.T) {
+	e := &userEntity{Name: "coco", Age: 9, Address: "wuhan", Phone: "130"}
+	{
+		sqlStr, ps := Insert(new(userEntity)).ToSQL(e)
+		if sqlStr != "INSERT INTO user_entity (name, age, address, phone) VALUES (?, ?, ?, ?)" || !reflect.DeepEqual(ps, []any{"coco", 9, "wuhan", "130"}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, ps := Insert(new(userEntity)).Ignore("phone").BatchToSQL(e, e)
+		if sqlStr != "INSERT INTO user_entity (name, age, address) VALUES (?, ?, ?), (?, ?, ?)" || !reflect.DeepEqual(ps, []any{"coco", 9, "wuhan", "coco", 9, "wuhan"}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		// the ignores of operation do not leak into the entity
+		if sqlStr, _ := Insert(new(userEntity)).ToSQL(e); sqlStr != "INSERT INTO user_entity (name, age, address, phone) VALUES (?, ?, ?, ?)" {
+			t.Fatal("test failed!")
+		}
+		if sqlStr, ps := Insert(new(userEntity)).BatchToSQL(); sqlStr != "" || ps != nil {
+			t.Fatal("test failed!")
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+type (
+	// Statement defines a SQL statement executed by anorm or xmlquery
+	Statement struct {
+		// Name defines the operation name, e.g. OpsForSelect.List
+		Name string
+		// SQL defines the statement text, an Interceptor can rewrite it
+		SQL string
+		// Args defines the statement arguments, an Interceptor can rewrite them
+		//
+		// Args are unmasked when the interceptors called, use LogArgs to log them
+		Args []any
+		// DS defines the datasource name
+		DS string
+
+		sensitive map[int]struct{}
+	}
+	// Invoker defines execute the Statement
+	//
+	// result is sql.Result for Exec, *sql.Rows for Query and *sql.Row for QueryRow
+	Invoker func(ctx context.Context, stmt *Statement) (result any, duration time.Duration, err error)
+	// Interceptor defines wrap the Statement execution, call invoker to continue the chain
+	//
+	// The returned duration is checked by the slow query log, see Logger.SetSlowThreshold
+	Interceptor func(ctx context.Context, stmt *Statement, invoker Invoker) (result any, duration time.Duration, err error)
+	// Runner defines run statements, both *sql.DB and *sql.Tx implement it
+	Runner interface {
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	}
+)
+
+var (
+	errStatementNoResult = errors.New("anorm: statement returns no result")
+
+	interceptorMu = &sync.Mutex{}
+	interceptors  = make([]Interceptor, 0)
+)
+
+// UseInterceptor append global interceptors, wraps statements of all datasources
+//
+// Global interceptors run before the datasource interceptors, see DataSourcePool.UseInterceptor
+func UseInterceptor(its ...Interceptor) {
+	interceptorMu.Lock()
+	defer interceptorMu.Unlock()
+	interceptors = append(interceptors, its...)
+}
+
+func getInterceptors(ds string) []Interceptor {
+	interceptorMu.Lock()
+	its := append(make([]Interceptor, 0), interceptors...)
+	interceptorMu.Unlock()
+	return append(its, DataSourcePool.getInterceptors(ds)...)
+}
+
+func invoke(ctx context.Context, stmt *Statement, run func(ctx context.Context, stmt *Statement) (any, error)) (any, error) {
+	stmt.unmask()
+	var invoker Invoker = func(ctx context.Context, stmt *Statement) (any, time.Duration, error) {
+		queryLog(stmt.Name, stmt.SQL, stmt.LogArgs())
+		start := time.Now()
+		result, err := run(ctx, stmt)
+		duration := time.Since(start)
+		queryErrorLog(err, stmt.Name, stmt.SQL, stmt.LogArgs())
+		return result, duration, err
+	}
+	its := getInterceptors(stmt.DS)
+	for i := len(its) - 1; i >= 0; i-- {
+		it, next := its[i], invoker
+		invoker = func(ctx context.Context, stmt *Statement) (any, time.Duration, error) { return it(ctx, stmt, next) }
+	}
+	result, duration, err := invoker(ctx, stmt)
+	slowQueryLog(stmt.Name, stmt.SQL, stmt.LogArgs(), duration, stmt.DS)
+	return result, err
+}
+
+// ExecStatement exec the Statement through the interceptors
+func ExecStatement(ctx context.Context, runner Runner, stmt *Statement) (sql.Result, error) {
+	result, err := invoke(ctx, stmt, func(ctx context.Context, stmt *Statement) (any, error) {
+		return runner.ExecContext(ctx, stmt.SQL, stmt.Args...)
+	})
+	r, _ := result.(sql.Result)
+	return r, err
+}
+
+// QueryStatement query the Statement through the interceptors
+func QueryStatement(ctx context.Context, runner Runner, stmt *Statement) (*sql.Rows, error) {
+	result, err := invoke(ctx, stmt, func(ctx context.Context, stmt *Statement) (any, error) {
+		return runner.QueryContext(ctx, stmt.SQL, stmt.Args...)
+	})
+	rows, _ := result.(*sql.Rows)
+	if err == nil && rows == nil {
+		err = errStatementNoResult
+	}
+	return rows, err
+}
+
+// QueryRowStatement query row the Statement through the interceptors
+func QueryRowStatement(ctx context.Context, runner Runner, stmt *Statement) (*sql.Row, error) {
+	result, err := invoke(ctx, stmt, func(ctx context.Context, stmt *Statement) (any, error) {
+		row := runner.QueryRowContext(ctx, stmt.SQL, stmt.Args...)
+		return row, row.Err()
+	})
+	row, _ := result.(*sql.Row)
+	if err == nil && row == nil {
+		err = errStatementNoResult
+	}
+	return row, err
+}
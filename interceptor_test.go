@@ -0,0 +1,104 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	testRunner struct {
+		sqlStr string
+		args   []any
+	}
+	testResult struct{}
+)
+
+func (r *testResult) LastInsertId() (int64, error) { return 0, nil }
+func (r *testResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (r *testRunner) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	r.sqlStr, r.args = query, args
+	return &testResult{}, nil
+}
+
+func (r *testRunner) QueryContext(_ context.Context, query string, args ...any) (*sql.Rows, error) {
+	r.sqlStr, r.args = query, args
+	return nil, errors.New("anorm: test runner can not query")
+}
+
+func (r *testRunner) QueryRowContext(_ context.Context, query string, args ...any) *sql.Row {
+	r.sqlStr, r.args = query, args
+	return nil
+}
+
+func TestInterceptor(t *testing.T) {
+	calls := make([]string, 0)
+	UseInterceptor(func(ctx context.Context, stmt *Statement, invoker Invoker) (any, time.Duration, error) {
+		if stmt.DS != "TestInterceptor" {
+			return invoker(ctx, stmt)
+		}
+		calls = append(calls, "global")
+		return invoker(ctx, stmt)
+	})
+	DataSourcePool.UseInterceptor("TestInterceptor", func(ctx context.Context, stmt *Statement, invoker Invoker) (any, time.Duration, error) {
+		calls = append(calls, "ds:"+stmt.Name)
+		stmt.SQL = strings.Replace(stmt.SQL, "t1", "t2", 1)
+		result, duration, err := invoker(ctx, stmt)
+		if duration < 0 {
+			t.Error("test failed")
+		}
+		return result, duration, err
+	})
+	r := &testRunner{}
+	if result, err := ExecStatement(context.Background(), r, &Statement{Name: "Test.Exec", SQL: "DELETE FROM t1 WHERE id = ?", Args: []any{1}, DS: "TestInterceptor"}); err != nil {
+		t.Fatalf("TestInterceptor failed: %v\n", err)
+	} else if ra, _ := result.RowsAffected(); ra != 1 {
+		t.Fatal("TestInterceptor failed!")
+	}
+	if r.sqlStr != "DELETE FROM t2 WHERE id = ?" || !reflect.DeepEqual(r.args, []any{1}) {
+		t.Fatal("TestInterceptor failed!")
+	}
+	if !reflect.DeepEqual(calls, []string{"global", "ds:Test.Exec"}) {
+		t.Fatal("TestInterceptor failed!")
+	}
+	calls = calls[:0]
+	if _, err := ExecStatement(context.Background(), r, &Statement{Name: "Test.Exec", SQL: "DELETE FROM t1", DS: "TestInterceptor_Other"}); err != nil {
+		t.Fatalf("TestInterceptor failed: %v\n", err)
+	}
+	if len(calls) != 0 || r.sqlStr != "DELETE FROM t1" {
+		t.Fatal("TestInterceptor failed!")
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	errAbort := errors.New("anorm: test abort")
+	DataSourcePool.UseInterceptor("TestInterceptorShortCircuit", func(ctx context.Context, stmt *Statement, invoker Invoker) (any, time.Duration, error) {
+		return nil, 0, errAbort
+	})
+	r := &testRunner{}
+	if _, err := QueryStatement(context.Background(), r, &Statement{Name: "Test.Query", SQL: "SELECT 1", DS: "TestInterceptorShortCircuit"}); !errors.Is(err, errAbort) {
+		t.Fatal("TestInterceptorShortCircuit failed!")
+	}
+	if _, err := QueryRowStatement(context.Background(), r, &Statement{Name: "Test.QueryRow", SQL: "SELECT 1", DS: "TestInterceptorShortCircuit"}); !errors.Is(err, errAbort) {
+		t.Fatal("TestInterceptorShortCircuit failed!")
+	}
+	if r.sqlStr != "" {
+		t.Fatal("TestInterceptorShortCircuit failed!")
+	}
+}
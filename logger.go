@@ -28,10 +28,21 @@ type (
 		low        io.Writer
 		level      logLevel
 		timeLayout string
+		slow       time.Duration
+		handler    LogHandler
 	}
 	logField struct{ k, v any }
 	logLevel uint8
 	LogF     = logField
+	LogLevel = logLevel
+	// LogHandler defines handle the records logged by anorm and xmlquery, see Logger.SetHandler
+	//
+	// The records are filtered by the log level before handled, except LogLevelSlow
+	LogHandler interface {
+		Handle(level LogLevel, fields []*LogF, message string)
+	}
+	// LogHandlerFunc defines a func as LogHandler
+	LogHandlerFunc func(level LogLevel, fields []*LogF, message string)
 )
 
 const (
@@ -45,6 +56,9 @@ const (
 	LogLevelFatal
 )
 
+// LogLevelSlow => log slow query level, emitted regardless of the log level
+const LogLevelSlow = logLevel(0x80)
+
 // Logger the global logger
 var (
 	Logger = &logger{
@@ -58,6 +72,7 @@ var (
 		LogLevelDebug: "DEBUG",
 		LogLevelError: "ERROR",
 		LogLevelFatal: "FATAL",
+		LogLevelSlow:  "SLOW",
 	}
 	queryLog = func(name, sql string, ps []any) {
 		Logger.Debug([]*logField{LogField("Name", name), LogField("SQL", sql), LogField("Parameter", ps)}, "")
@@ -67,9 +82,29 @@ var (
 			Logger.Error([]*logField{LogField("Name", name), LogField("SQL", sql), LogField("Parameter", ps)}, "err: %v", err)
 		}
 	}
+	slowQueryLog = func(name, sql string, ps []any, duration time.Duration, ds string) {
+		if threshold := Logger.getSlowThreshold(); threshold > 0 && duration >= threshold {
+			Logger.Slow([]*logField{LogField("Name", name), LogField("SQL", sql), LogField("Parameter", ps), LogField("Duration", duration), LogField("DS", ds)}, "exceeds %v", threshold)
+		}
+	}
 	LogField = func(k, v any) *logField { return &logField{k, v} }
 )
 
+// Handle call f
+func (f LogHandlerFunc) Handle(level LogLevel, fields []*LogF, message string) {
+	f(level, fields, message)
+}
+
+// Key return the field key
+func (f *logField) Key() string {
+	return fmt.Sprintf("%v", f.k)
+}
+
+// Value return the field value
+func (f *logField) Value() any {
+	return f.v
+}
+
 func (l *logger) getValidLevel(level logLevel) logLevel {
 	if level < LogLevelInfo {
 		level = LogLevelInfo
@@ -81,7 +116,9 @@ func (l *logger) getValidLevel(level logLevel) logLevel {
 }
 
 func (l *logger) getLogName(level logLevel) string {
-	level = l.getValidLevel(level)
+	if level != LogLevelSlow {
+		level = l.getValidLevel(level)
+	}
 	return logLevelMap[level]
 }
 
@@ -99,6 +136,30 @@ func (l *logger) SetTimeLayout(layout string) {
 	l.timeLayout = layout
 }
 
+// SetSlowThreshold set slow query threshold, statements take longer are logged as SLOW
+//
+// A zero or negative threshold disables the slow query log
+func (l *logger) SetSlowThreshold(threshold time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.slow = threshold
+}
+
+func (l *logger) getSlowThreshold() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.slow
+}
+
+// SetHandler set log handler, nil restores the default text handler
+//
+// The handler replaces the text output, SetTimeLayout and SetOutput take no effect on it
+func (l *logger) SetHandler(handler LogHandler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handler = handler
+}
+
 // SetOutput set log output
 func (l *logger) SetOutput(low io.Writer) {
 	l.mu.Lock()
@@ -126,22 +187,37 @@ func (l *logger) Fatal(fields []*logField, message string, args ...any) {
 	l.log(LogLevelFatal, fields, message, args...)
 }
 
+// Slow display slow query log
+func (l *logger) Slow(fields []*logField, message string, args ...any) {
+	l.log(LogLevelSlow, fields, message, args...)
+}
+
 func (l *logger) log(level logLevel, fields []*logField, message string, args ...any) {
 	l.mu.Lock()
+	if level != LogLevelSlow {
+		level = l.getValidLevel(level)
+	}
+	if level != LogLevelSlow && level < l.level {
+		l.mu.Unlock()
+		return
+	}
+	if handler := l.handler; handler != nil {
+		// unlock before handle, the handler may log through Logger
+		l.mu.Unlock()
+		handler.Handle(level, fields, fmt.Sprintf(message, args...))
+		return
+	}
 	defer l.mu.Unlock()
-	level = l.getValidLevel(level)
-	if level >= l.level {
-		logArr := make([]string, 0)
-		logArr = append(logArr, fmt.Sprintf("[%s]", time.Now().Format(l.timeLayout)))
-		logArr = append(logArr, fmt.Sprintf("%s -", l.getLogName(level)))
-		if fields != nil {
-			for _, f := range fields {
-				k, v := f.k, f.v
-				logArr = append(logArr, fmt.Sprintf("%v{%v}", k, v))
-			}
+	logArr := make([]string, 0)
+	logArr = append(logArr, fmt.Sprintf("[%s]", time.Now().Format(l.timeLayout)))
+	logArr = append(logArr, fmt.Sprintf("%s -", l.getLogName(level)))
+	if fields != nil {
+		for _, f := range fields {
+			k, v := f.k, f.v
+			logArr = append(logArr, fmt.Sprintf("%v{%v}", k, v))
 		}
-		logArr = append(logArr, fmt.Sprintf(message, args...))
-		logStr := strings.Join(logArr, " ")
-		l.lo.Println(logStr)
 	}
+	logArr = append(logArr, fmt.Sprintf(message, args...))
+	logStr := strings.Join(logArr, " ")
+	l.lo.Println(logStr)
 }
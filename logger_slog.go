@@ -0,0 +1,51 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package anorm
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type slogHandler struct{ h slog.Handler }
+
+// LogLevelFatal and LogLevelSlow have no slog level, map them to ERROR+4 and WARN
+var slogLevelMap = map[logLevel]slog.Level{
+	LogLevelInfo:  slog.LevelInfo,
+	LogLevelDebug: slog.LevelDebug,
+	LogLevelError: slog.LevelError,
+	LogLevelFatal: slog.LevelError + 4,
+	LogLevelSlow:  slog.LevelWarn,
+}
+
+// NewSlogHandler return a LogHandler emits records to the slog.Handler, fields as attributes
+//
+//	Logger.SetHandler(NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+func NewSlogHandler(h slog.Handler) LogHandler {
+	return &slogHandler{h}
+}
+
+func (s *slogHandler) Handle(level LogLevel, fields []*LogF, message string) {
+	ctx := context.Background()
+	sl := slogLevelMap[level]
+	if !s.h.Enabled(ctx, sl) {
+		return
+	}
+	r := slog.NewRecord(time.Now(), sl, message, 0)
+	for _, f := range fields {
+		r.AddAttrs(slog.Any(f.Key(), f.Value()))
+	}
+	_ = s.h.Handle(ctx, r)
+}
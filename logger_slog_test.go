@@ -0,0 +1,39 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package anorm
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	Logger.SetLogLevel(LogLevelInfo)
+	Logger.SetHandler(NewSlogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer Logger.SetHandler(nil)
+	Logger.Error([]*logField{LogField("SQL", "SELECT ?"), LogField("Parameter", []any{1})}, "err: %v", "hello")
+	record := make(map[string]any)
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("TestNewSlogHandler failed: %v\n", err)
+	}
+	if record["level"] != "ERROR" || record["msg"] != "err: hello" || record["SQL"] != "SELECT ?" {
+		t.Error("test failed")
+	}
+	if ps, ok := record["Parameter"].([]any); !ok || len(ps) != 1 || ps[0] != float64(1) {
+		t.Error("test failed")
+	}
+}
@@ -164,3 +164,71 @@ func TestLogger_Fatal(t *testing.T) {
 		t.Error("test failed")
 	}
 }
+
+func TestLogger_Slow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	layout := "2006-01-02"
+	Logger.SetTimeLayout(layout)
+	Logger.SetLogLevel(LogLevelFatal)
+	Logger.SetOutput(buf)
+	Logger.Slow(nil, "hello world")
+	expect := fmt.Sprintf("[anorm] [%s] SLOW - hello world\n", time.Now().Format(layout))
+	if s := buf.String(); s != expect {
+		t.Error("test failed")
+	}
+}
+
+func TestLogger_SetSlowThreshold(t *testing.T) {
+	buf := &bytes.Buffer{}
+	layout := "2006-01-02"
+	Logger.SetTimeLayout(layout)
+	Logger.SetLogLevel(LogLevelFatal)
+	Logger.SetOutput(buf)
+	defer Logger.SetSlowThreshold(0)
+	for _, tc := range []struct {
+		testName  string
+		threshold time.Duration
+		duration  time.Duration
+		expect    string
+	}{
+		{"Test-disabled", 0, time.Second, ""},
+		{"Test-fast", time.Second, time.Millisecond, ""},
+		{"Test-slow", time.Second, 2 * time.Second, fmt.Sprintf("[anorm] [%s] SLOW - Name{Test} SQL{SELECT ?} Parameter{[1]} Duration{2s} DS{_} exceeds 1s\n", time.Now().Format(layout))},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			Logger.SetSlowThreshold(tc.threshold)
+			slowQueryLog("Test", "SELECT ?", []any{1}, tc.duration, "_")
+			if s := buf.String(); s != tc.expect {
+				t.Error("test failed")
+			}
+			buf.Reset()
+		})
+	}
+}
+
+func TestLogger_SetHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	Logger.SetLogLevel(LogLevelError)
+	Logger.SetOutput(buf)
+	handled := make([]string, 0)
+	Logger.SetHandler(LogHandlerFunc(func(level LogLevel, fields []*LogF, message string) {
+		for _, f := range fields {
+			handled = append(handled, fmt.Sprintf("%s=%v", f.Key(), f.Value()))
+		}
+		handled = append(handled, logLevelMap[level]+":"+message)
+	}))
+	Logger.Debug(nil, "hello world")
+	Logger.Error([]*logField{LogField("ID", 1000)}, "hello world-%d", 10)
+	Logger.Slow(nil, "hello world")
+	Logger.SetHandler(nil)
+	if len(handled) != 3 || handled[0] != "ID=1000" || handled[1] != "ERROR:hello world-10" || handled[2] != "SLOW:hello world" {
+		t.Error("test failed")
+	}
+	if buf.Len() != 0 {
+		t.Error("test failed")
+	}
+	Logger.Error(nil, "hello world")
+	if buf.Len() == 0 {
+		t.Error("test failed")
+	}
+}
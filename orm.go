@@ -27,6 +27,12 @@ var (
 	errUnknownEntity = func(entity EntityConfigurator) error {
 		return errors.New(fmt.Sprintf("anorm: unknown entity [%v]", getEntityPkgName(entity)))
 	}
+	errUnknownField = func(entity EntityConfigurator, field string) error {
+		return errors.New(fmt.Sprintf("anorm: unknown field [%s] of entity [%v]", field, getEntityPkgName(entity)))
+	}
+	errEntityNoPK = func(entity EntityConfigurator) error {
+		return errors.New(fmt.Sprintf("anorm: entity [%v] has no primary key", getEntityPkgName(entity)))
+	}
 	errTxNotOpen            = errors.New("anorm: tx not open")
 	errTxManagerNil         = errors.New("anorm: tx manager is nil")
 	errAlreadyBindTxManager = errors.New("anorm: already bind tx manager")
@@ -38,7 +44,9 @@ type (
 	}
 	Orm[E Entity] struct {
 		mu     *sync.Mutex
+		ctx    context.Context
 		entity E
+		ds     string
 		db     *sql.DB
 		openTx bool
 		tx     *sql.Tx
@@ -64,7 +72,9 @@ func NewWithDS[E Entity](entity E, ds string) *Orm[E] {
 
 	o := &Orm[E]{
 		mu:     &sync.Mutex{},
+		ctx:    context.Background(),
 		entity: entity,
+		ds:     ds,
 		db:     DataSourcePool.Required(ds),
 	}
 
@@ -79,12 +89,46 @@ func NewWithDS[E Entity](entity E, ds string) *Orm[E] {
 	return o
 }
 
+// WithContext defines set the context passed to the entity hooks
+func (o *Orm[E]) WithContext(ctx context.Context) *Orm[E] {
+	if ctx != nil {
+		o.ctx = ctx
+	}
+	return o
+}
+
+func (o *Orm[E]) runner() Runner {
+	if o.openTx {
+		return o.tx
+	}
+	return o.db
+}
+
+func (o *Orm[E]) exec(name, sqlStr string, ps []any) (sql.Result, error) {
+	return ExecStatement(o.ctx, o.runner(), &Statement{Name: name, SQL: sqlStr, Args: ps, DS: o.ds})
+}
+
+func (o *Orm[E]) query(name, sqlStr string, ps []any) (*sql.Rows, error) {
+	return QueryStatement(o.ctx, o.db, &Statement{Name: name, SQL: sqlStr, Args: ps, DS: o.ds})
+}
+
+func (o *Orm[E]) queryRow(name, sqlStr string, ps []any) (*sql.Row, error) {
+	return QueryRowStatement(o.ctx, o.db, &Statement{Name: name, SQL: sqlStr, Args: ps, DS: o.ds})
+}
+
+func (o *Orm[E]) explain(name, sqlStr string, ps []any) (*Plan, error) {
+	return ExplainStatement(o.ctx, o.db, &Statement{Name: name, SQL: sqlStr, Args: ps, DS: o.ds})
+}
+
 // table defines return EntityConfigurator's table name
 func (o *Orm[E]) table() sg.Ge {
 	return sg.T(entityTableMap[getEntityPkgName(o.entity)])
 }
 
-func (o *Orm[E]) getWhereGes(entity E) []sg.Ge {
+// getWhereGes return the wheres of the entity non-zero fields
+//
+// The fields of matchFields are included even zero, the pointer fields dereferenced, a nil pointer is IS NULL
+func (o *Orm[E]) getWhereGes(entity E, matchFields map[string]struct{}) []sg.Ge {
 	ges := make([]sg.Ge, 0)
 	if entityNotNil(entity) {
 		fieldColumnMap := entityFieldColumnMap[getEntityPkgName(entity)]
@@ -93,14 +137,62 @@ func (o *Orm[E]) getWhereGes(entity E) []sg.Ge {
 		for i := 0; i < rv.NumField(); i++ {
 			field := rt.Field(i)
 			value := rv.Field(i)
-			if val := o.getRealVal(value); val != nil {
-				ges = append(ges, sg.Eq(sg.C(fieldColumnMap[field.Name]), val))
+			column, mapped := fieldColumnMap[field.Name]
+			if _, match := matchFields[field.Name]; match && mapped {
+				if value.Kind() == reflect.Ptr && value.IsNil() {
+					ges = append(ges, newFuncGe(fmt.Sprintf("(%s IS NULL)", column)))
+					continue
+				}
+				if value.Kind() == reflect.Ptr {
+					value = value.Elem()
+				}
+				ges = append(ges, sg.Eq(sg.C(column), sensitiveValue(getEntityPkgName(entity), column, value.Interface())))
+			} else if val := o.getRealVal(value); val != nil {
+				ges = append(ges, sg.Eq(sg.C(column), sensitiveValue(getEntityPkgName(entity), column, val)))
 			}
 		}
 	}
 	return ges
 }
 
+// getMatchFields return the field names of the field or column names, or else the unknown field error
+func (o *Orm[E]) getMatchFields(matchFields map[string]struct{}, fields ...string) (map[string]struct{}, error) {
+	if matchFields == nil {
+		matchFields = make(map[string]struct{}, 0)
+	}
+	columnFieldMap := entityColumnFieldMap[getEntityPkgName(o.entity)]
+	fieldColumnMap := entityFieldColumnMap[getEntityPkgName(o.entity)]
+	for _, f := range fields {
+		fieldName, have := columnFieldMap[f]
+		if !have {
+			fieldName = f
+		}
+		if _, have = fieldColumnMap[fieldName]; !have {
+			return matchFields, errUnknownField(o.entity, f)
+		}
+		matchFields[fieldName] = struct{}{}
+	}
+	return matchFields, nil
+}
+
+// getPKWhereGes return the primary key wheres of the entity, zero values included
+func (o *Orm[E]) getPKWhereGes(entity E) ([]sg.Ge, error) {
+	pks := entityPKMap[getEntityPkgName(o.entity)]
+	if len(pks) <= 0 {
+		return nil, errEntityNoPK(o.entity)
+	}
+	if !entityNotNil(entity) {
+		return nil, errEntityNil
+	}
+	columnFieldMap := entityColumnFieldMap[getEntityPkgName(o.entity)]
+	rv := reflect.ValueOf(entity).Elem()
+	ges := make([]sg.Ge, 0, len(pks))
+	for _, pk := range pks {
+		ges = append(ges, sg.Eq(sg.C(pk), sensitiveValue(getEntityPkgName(o.entity), pk, rv.FieldByName(columnFieldMap[pk]).Interface())))
+	}
+	return ges, nil
+}
+
 func (o *Orm[E]) getRealVal(value reflect.Value) (val any) {
 	if value.Kind() == reflect.Ptr {
 		return o.getRealVal(value.Elem())
@@ -177,6 +269,11 @@ func (o *Orm[E]) OpsForSelectCount() SelectCountOperation[E] {
 	return newSelectCountOperation(o)
 }
 
+// OpsForAggregate defines return *aggregateOperation
+func (o *Orm[E]) OpsForAggregate() AggregateOperation[E] {
+	return newAggregateOperation(o)
+}
+
 // OpsForInsert defines return *insertOperation
 func (o *Orm[E]) OpsForInsert() InsertOperation[E] {
 	return newInsertOperation(o)
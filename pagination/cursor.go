@@ -0,0 +1,84 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor define the keyset position, encoded as an opaque token
+type Cursor struct {
+	// Values define the key values of the position row
+	Values []any `json:"v"`
+	// Before define fetch the rows before the position row
+	Before bool `json:"b,omitempty"`
+
+	raw []json.RawMessage
+}
+
+var (
+	// ErrInvalidCursor define the token is not a valid cursor
+	ErrInvalidCursor = errors.New("pagination: invalid cursor")
+)
+
+// Encode return the opaque token of the cursor
+func (c *Cursor) Encode() string {
+	bs, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+// DecodeCursor return the cursor of the token, the integer values decoded as int64, the other numbers as float64
+func DecodeCursor(token string) (*Cursor, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	rc := &struct {
+		Values []json.RawMessage `json:"v"`
+		Before bool              `json:"b,omitempty"`
+	}{}
+	if err = json.Unmarshal(bs, rc); err != nil || len(rc.Values) <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	c := &Cursor{Values: make([]any, len(rc.Values)), Before: rc.Before, raw: rc.Values}
+	for i, r := range rc.Values {
+		decoder := json.NewDecoder(bytes.NewReader(r))
+		decoder.UseNumber()
+		if err = decoder.Decode(&c.Values[i]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		if n, ok := c.Values[i].(json.Number); ok {
+			if iv, err2 := n.Int64(); err2 == nil {
+				c.Values[i] = iv
+			} else if fv, err2 := n.Float64(); err2 == nil {
+				c.Values[i] = fv
+			}
+		}
+	}
+	return c, nil
+}
+
+// Scan decode the values of the decoded cursor into the dests, keep the types of the dests such as time.Time
+func (c *Cursor) Scan(dests ...any) error {
+	if len(dests) != len(c.raw) {
+		return ErrInvalidCursor
+	}
+	for i, dest := range dests {
+		if err := json.Unmarshal(c.raw[i], dest); err != nil {
+			return ErrInvalidCursor
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect defines the database specific SQL, MySql, Pg and SqlServer implement it
+type Dialect interface {
+	Pager
+	// Limit return the sql skips offset rows and returns limit rows, a zero or negative limit means no limit
+	Limit(sql string, offset, limit int) (sqlStr string, args []any)
+	// Seek return the keyset predicate of the columns after the values, before the values if desc
+	//
+	// e.g. (k1, k2) > (?, ?)
+	Seek(columns []string, values []any, desc bool) (sqlStr string, args []any)
+	// Exists return the sql selects whether the sql returns any row
+	Exists(sql string) string
+	// Explain return the EXPLAIN statement of sql, return empty if the database not supported
+	Explain(sql string) string
+	// Estimate return the estimated rows of the plan rows returned by Explain
+	Estimate(rows []map[string]any) int64
+	// FullScan return true if the plan row is a full table scan
+	FullScan(row map[string]any) bool
+}
+
+// rowSeek return the row value comparison, e.g. (k1, k2) > (?, ?)
+func rowSeek(columns []string, values []any, desc bool) (string, []any) {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	marks := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, marks), values
+}
+
+// expandSeek return the expanded comparison, e.g. (k1 > ?) OR (k1 = ? AND k2 > ?)
+func expandSeek(columns []string, values []any, desc bool) (string, []any) {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	ors := make([]string, 0, len(columns))
+	args := make([]any, 0)
+	for i := range columns {
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, columns[j]+" = ?")
+			args = append(args, values[j])
+		}
+		ands = append(ands, columns[i]+" "+op+" ?")
+		args = append(args, values[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// parseNumber return the float value of v, scanned values may be string
+func parseNumber(v any) float64 {
+	f, _ := strconv.ParseFloat(fmt.Sprintf("%v", v), 64)
+	return f
+}
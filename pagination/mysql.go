@@ -11,9 +11,58 @@
 
 package pagination
 
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
 type mysql struct{}
 
 // Page mysql implementation
 func (m *mysql) Page(sql string, offset, size int) (sqlStr string, args []any) {
 	return sql + " LIMIT ?, ?", []any{offset, size}
 }
+
+// Explain mysql implementation
+func (m *mysql) Explain(sql string) string {
+	return "EXPLAIN " + sql
+}
+
+// FullScan mysql implementation, the access type is ALL
+func (m *mysql) FullScan(row map[string]any) bool {
+	return strings.EqualFold(fmt.Sprintf("%v", row["type"]), "ALL")
+}
+
+// Limit mysql implementation, a zero or negative limit means no limit
+func (m *mysql) Limit(sql string, offset, limit int) (sqlStr string, args []any) {
+	if limit <= 0 {
+		return sql + " LIMIT ?, ?", []any{offset, int64(math.MaxInt64)}
+	}
+	if offset <= 0 {
+		return sql + " LIMIT ?", []any{limit}
+	}
+	return m.Page(sql, offset, limit)
+}
+
+// Exists mysql implementation
+func (m *mysql) Exists(sql string) string {
+	return "SELECT EXISTS(" + sql + ")"
+}
+
+// Seek mysql implementation, row value comparison
+func (m *mysql) Seek(columns []string, values []any, desc bool) (sqlStr string, args []any) {
+	return rowSeek(columns, values, desc)
+}
+
+// Estimate mysql implementation, rows * filtered% of the first table
+func (m *mysql) Estimate(rows []map[string]any) int64 {
+	if len(rows) <= 0 {
+		return 0
+	}
+	estimated := parseNumber(rows[0]["rows"])
+	if filtered, have := rows[0]["filtered"]; have && filtered != nil {
+		estimated = estimated * parseNumber(filtered) / 100
+	}
+	return int64(estimated)
+}
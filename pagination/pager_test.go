@@ -12,8 +12,10 @@
 package pagination
 
 import (
+	"math"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestPager(t *testing.T) {
@@ -38,3 +40,136 @@ func TestPager(t *testing.T) {
 		}
 	}
 }
+
+func TestDialect(t *testing.T) {
+	{
+		if MySql.Explain("haha") != "EXPLAIN haha" || !MySql.FullScan(map[string]any{"type": "ALL"}) || MySql.FullScan(map[string]any{"type": "ref"}) {
+			t.Error("test failed")
+		}
+	}
+
+	{
+		if Pg.Explain("haha") != "EXPLAIN haha" || !Pg.FullScan(map[string]any{"QUERY PLAN": "Seq Scan on t  (cost=0.00..1.01 rows=1 width=4)"}) || Pg.FullScan(map[string]any{"QUERY PLAN": "Index Scan using t_pkey on t"}) {
+			t.Error("test failed")
+		}
+	}
+
+	{
+		if SqlServer("id asc").Explain("haha") != "" || !SqlServer("id asc").FullScan(map[string]any{"PhysicalOp": "Table Scan"}) {
+			t.Error("test failed")
+		}
+	}
+}
+
+func TestDialect_Limit(t *testing.T) {
+	{
+		if sqlStr, args := MySql.Limit("haha", 0, 10); sqlStr != "haha LIMIT ?" || !reflect.DeepEqual([]any{10}, args) {
+			t.Error("test failed")
+		}
+		if sqlStr, args := MySql.Limit("haha", 5, 10); sqlStr != "haha LIMIT ?, ?" || !reflect.DeepEqual([]any{5, 10}, args) {
+			t.Error("test failed")
+		}
+		if sqlStr, args := MySql.Limit("haha", 5, 0); sqlStr != "haha LIMIT ?, ?" || !reflect.DeepEqual([]any{5, int64(math.MaxInt64)}, args) {
+			t.Error("test failed")
+		}
+	}
+
+	{
+		if sqlStr, args := Pg.Limit("haha", 0, 10); sqlStr != "haha LIMIT ?" || !reflect.DeepEqual([]any{10}, args) {
+			t.Error("test failed")
+		}
+		if sqlStr, args := Pg.Limit("haha", 5, 10); sqlStr != "haha LIMIT ? OFFSET ?" || !reflect.DeepEqual([]any{10, 5}, args) {
+			t.Error("test failed")
+		}
+		if sqlStr, args := Pg.Limit("haha", 5, 0); sqlStr != "haha OFFSET ?" || !reflect.DeepEqual([]any{5}, args) {
+			t.Error("test failed")
+		}
+	}
+
+	{
+		if sqlStr, args := SqlServer("id asc").Limit("haha", 5, 0); sqlStr != "haha ORDER BY id asc OFFSET ? ROWS" || !reflect.DeepEqual([]any{5}, args) {
+			t.Error("test failed")
+		}
+		if sqlStr, args := SqlServer("id asc").Limit("SELECT a FROM t ORDER BY a DESC", 0, 10); sqlStr != "SELECT a FROM t ORDER BY a DESC OFFSET ? ROWS FETCH NEXT ? ROWS ONLY" || !reflect.DeepEqual([]any{0, 10}, args) {
+			t.Error("test failed")
+		}
+		if sqlStr, args := SqlServer("id asc").Limit("SELECT a FROM t WHERE a IN (SELECT b FROM u ORDER BY b)", 5, 10); sqlStr != "SELECT a FROM t WHERE a IN (SELECT b FROM u ORDER BY b) ORDER BY id asc OFFSET ? ROWS FETCH NEXT ? ROWS ONLY" || !reflect.DeepEqual([]any{5, 10}, args) {
+			t.Error("test failed")
+		}
+	}
+}
+
+func TestDialect_Exists(t *testing.T) {
+	if MySql.Exists("haha") != "SELECT EXISTS(haha)" || Pg.Exists("haha") != "SELECT EXISTS(haha)" {
+		t.Error("test failed")
+	}
+	if SqlServer("id asc").Exists("haha") != "SELECT CASE WHEN EXISTS(haha) THEN 1 ELSE 0 END" {
+		t.Error("test failed")
+	}
+}
+
+func TestDialect_Seek(t *testing.T) {
+	{
+		sqlStr, args := MySql.Seek([]string{"t.a", "t.b"}, []any{1, 2}, false)
+		if sqlStr != "(t.a, t.b) > (?, ?)" || !reflect.DeepEqual([]any{1, 2}, args) {
+			t.Error("test failed")
+		}
+	}
+
+	{
+		sqlStr, args := Pg.Seek([]string{"t.a"}, []any{1}, true)
+		if sqlStr != "(t.a) < (?)" || !reflect.DeepEqual([]any{1}, args) {
+			t.Error("test failed")
+		}
+	}
+
+	{
+		sqlStr, args := SqlServer("id asc").Seek([]string{"t.a", "t.b"}, []any{1, 2}, false)
+		if sqlStr != "((t.a > ?) OR (t.a = ? AND t.b > ?))" || !reflect.DeepEqual([]any{1, 1, 2}, args) {
+			t.Error("test failed")
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	token := (&Cursor{Values: []any{"coco", 10, 1.5}, Before: true}).Encode()
+	if c, err := DecodeCursor(token); err != nil {
+		t.Error("test failed")
+	} else if !reflect.DeepEqual(c.Values, []any{"coco", int64(10), 1.5}) || !c.Before {
+		t.Error("test failed")
+	}
+	now := time.Now()
+	if c, err := DecodeCursor((&Cursor{Values: []any{now, 10}}).Encode()); err != nil {
+		t.Error("test failed")
+	} else {
+		var tv time.Time
+		var iv int
+		if err = c.Scan(&tv, &iv); err != nil || !tv.Equal(now) || iv != 10 {
+			t.Error("test failed")
+		}
+		if err = c.Scan(&tv); err != ErrInvalidCursor {
+			t.Error("test failed")
+		}
+		if err = c.Scan(&iv, &tv); err != ErrInvalidCursor {
+			t.Error("test failed")
+		}
+	}
+	if _, err := DecodeCursor("haha"); err != ErrInvalidCursor {
+		t.Error("test failed")
+	}
+	if _, err := DecodeCursor((&Cursor{}).Encode()); err != ErrInvalidCursor {
+		t.Error("test failed")
+	}
+}
+
+func TestDialect_Estimate(t *testing.T) {
+	if MySql.Estimate([]map[string]any{{"rows": "200", "filtered": "50.00"}}) != 100 || MySql.Estimate([]map[string]any{{"rows": int64(10)}}) != 10 || MySql.Estimate(nil) != 0 {
+		t.Error("test failed")
+	}
+	if Pg.Estimate([]map[string]any{{"QUERY PLAN": "Seq Scan on t  (cost=0.00..35.50 rows=2550 width=4)"}}) != 2550 || Pg.Estimate(nil) != 0 {
+		t.Error("test failed")
+	}
+	if SqlServer("id asc").Estimate([]map[string]any{{"EstimateRows": 12.5}}) != 12 {
+		t.Error("test failed")
+	}
+}
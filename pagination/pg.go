@@ -11,9 +11,59 @@
 
 package pagination
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 type pg struct{}
 
 // Page pgsql implementation
 func (p *pg) Page(sql string, offset, size int) (sqlStr string, args []any) {
 	return sql + " LIMIT ? OFFSET ?", []any{size, offset}
 }
+
+// Explain pgsql implementation
+func (p *pg) Explain(sql string) string {
+	return "EXPLAIN " + sql
+}
+
+// FullScan pgsql implementation, the plan node is Seq Scan
+func (p *pg) FullScan(row map[string]any) bool {
+	return strings.Contains(fmt.Sprintf("%v", row["QUERY PLAN"]), "Seq Scan")
+}
+
+// Limit pgsql implementation, a zero or negative limit means no limit
+func (p *pg) Limit(sql string, offset, limit int) (sqlStr string, args []any) {
+	if limit <= 0 {
+		return sql + " OFFSET ?", []any{offset}
+	}
+	if offset <= 0 {
+		return sql + " LIMIT ?", []any{limit}
+	}
+	return p.Page(sql, offset, limit)
+}
+
+// Exists pgsql implementation
+func (p *pg) Exists(sql string) string {
+	return "SELECT EXISTS(" + sql + ")"
+}
+
+// Seek pgsql implementation, row value comparison
+func (p *pg) Seek(columns []string, values []any, desc bool) (sqlStr string, args []any) {
+	return rowSeek(columns, values, desc)
+}
+
+var pgPlanRows = regexp.MustCompile(`rows=(\d+)`)
+
+// Estimate pgsql implementation, the rows of the top plan node
+func (p *pg) Estimate(rows []map[string]any) int64 {
+	if len(rows) <= 0 {
+		return 0
+	}
+	if ms := pgPlanRows.FindStringSubmatch(fmt.Sprintf("%v", rows[0]["QUERY PLAN"])); len(ms) == 2 {
+		return int64(parseNumber(ms[1]))
+	}
+	return 0
+}
@@ -11,7 +11,10 @@
 
 package pagination
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type sqlServer struct{ string }
 
@@ -19,3 +22,45 @@ type sqlServer struct{ string }
 func (s *sqlServer) Page(sql string, offset, size int) (sqlStr string, args []any) {
 	return fmt.Sprintf("SELECT t.* FROM (SELECT _t.*, row_number() over (order by %s) as rn FROM (%s) as _t) WHERE t.rn between ? and ?", s.string, sql), []any{offset + 1, offset + size}
 }
+
+// Explain sqlserver implementation, SHOWPLAN must be set in a separate batch, so it's not supported
+func (s *sqlServer) Explain(string) string {
+	return ""
+}
+
+// FullScan sqlserver implementation, the physical operator is Table Scan
+func (s *sqlServer) FullScan(row map[string]any) bool {
+	return fmt.Sprintf("%v", row["PhysicalOp"]) == "Table Scan"
+}
+
+// Limit sqlserver implementation, a zero or negative limit means no limit
+//
+// OFFSET FETCH requires ORDER BY, the order of the pager appended if the sql has none
+func (s *sqlServer) Limit(sql string, offset, limit int) (sqlStr string, args []any) {
+	sqlStr = sql
+	if upper := strings.ToUpper(sql); strings.LastIndex(upper, "ORDER BY") <= strings.LastIndex(upper, ")") {
+		sqlStr += " ORDER BY " + s.string
+	}
+	if limit <= 0 {
+		return sqlStr + " OFFSET ? ROWS", []any{offset}
+	}
+	return sqlStr + " OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", []any{offset, limit}
+}
+
+// Exists sqlserver implementation, EXISTS is not an expression
+func (s *sqlServer) Exists(sql string) string {
+	return "SELECT CASE WHEN EXISTS(" + sql + ") THEN 1 ELSE 0 END"
+}
+
+// Seek sqlserver implementation, row value comparison is not supported, expanded
+func (s *sqlServer) Seek(columns []string, values []any, desc bool) (sqlStr string, args []any) {
+	return expandSeek(columns, values, desc)
+}
+
+// Estimate sqlserver implementation, the EstimateRows of the first plan row
+func (s *sqlServer) Estimate(rows []map[string]any) int64 {
+	if len(rows) <= 0 {
+		return 0
+	}
+	return int64(parseNumber(rows[0]["EstimateRows"]))
+}
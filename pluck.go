@@ -0,0 +1,68 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Pluck select the field of the operation's where, order, join and limit, scan into []T
+//
+// The field accepts field or column name, call Distinct on the operation to pluck distinct values
+func Pluck[E Entity, T any](op SelectOperation[E], field string) ([]T, error) {
+	o, ok := op.(*selectOperation[E])
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("anorm: pluck unsupported operation %T", op))
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	if _, have := o.getFieldColumn(field); !have {
+		return nil, errUnknownField(o.orm.entity, field)
+	}
+	po := *o
+	po.selectFields = map[string]struct{}{o.getFieldName(field): {}}
+	po.omitFields = make(map[string]struct{}, 0)
+	var nilE E
+	sqlStr, ps := po.getListBuilder(nilE)
+	rows, err := o.orm.query("OpsForSelect.Pluck", sqlStr, ps)
+	if err != nil {
+		return nil, err
+	}
+	return scanColumn[T](rows)
+}
+
+// PluckDistinct select the distinct field, see Pluck, op is not changed to distinct
+func PluckDistinct[E Entity, T any](op SelectOperation[E], field string) ([]T, error) {
+	o, ok := op.(*selectOperation[E])
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("anorm: pluck unsupported operation %T", op))
+	}
+	po := *o
+	po.distinct = true
+	return Pluck[E, T](&po, field)
+}
+
+func scanColumn[T any](rows *sql.Rows) ([]T, error) {
+	defer func() { _ = rows.Close() }()
+	ts := make([]T, 0)
+	for rows.Next() {
+		var t T
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		ts = append(ts, t)
+	}
+	return ts, rows.Err()
+}
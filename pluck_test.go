@@ -0,0 +1,46 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"github.com/go-the-way/sg"
+	"reflect"
+	"testing"
+)
+
+func TestPluck(t *testing.T) {
+	truncateTestTable()
+	_ = insertUserEntity("coco", 10, "wuhan", "130")
+	_ = insertUserEntity("coco", 20, "wuhan", "131")
+	_ = insertUserEntity("hugo", 30, "beijing", "132")
+	if ids, err := Pluck[*userEntity, int](Select(new(userEntity)).Where(sg.Gt("age", 10)).OrderBy(sg.Desc(sg.C("id"))), "ID"); err != nil {
+		t.Fatalf("TestPluck failed: %v\n", err)
+	} else if !reflect.DeepEqual(ids, []int{3, 2}) {
+		t.Fatal("TestPluck failed!")
+	}
+	if names, err := PluckDistinct[*userEntity, string](Select(new(userEntity)).OrderBy(sg.C("name")), "name"); err != nil {
+		t.Fatalf("TestPluck failed: %v\n", err)
+	} else if !reflect.DeepEqual(names, []string{"coco", "hugo"}) {
+		t.Fatal("TestPluck failed!")
+	}
+	if _, err := Pluck[*userEntity, string](Select(new(userEntity)), "Unknown"); err == nil {
+		t.Fatal("TestPluck failed!")
+	}
+}
+
+func TestPluckDistinctKeepOp(t *testing.T) {
+	op := Select(new(userEntity)).Columns("ID")
+	_, _ = PluckDistinct[*userEntity, string](op, "name")
+	if sqlStr, _ := op.ToSQL(nil); sqlStr != "SELECT t.id AS ID FROM user_entity AS t" {
+		t.Fatalf("test failed! %s", sqlStr)
+	}
+}
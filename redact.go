@@ -0,0 +1,82 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+type sensitiveArg struct{ arg any }
+
+var (
+	// Redact defines return the logged form of a sensitive argument
+	Redact = func(arg any) any { return "******" }
+)
+
+// Sensitive marks the argument sensitive, it's executed as is and masked in the logs
+//
+// The arguments of the entity columns tagged `sensitive{T}` or configured by EC.SensitiveColumns marked automatically
+func Sensitive(arg any) any {
+	if _, ok := arg.(*sensitiveArg); ok {
+		return arg
+	}
+	return &sensitiveArg{arg}
+}
+
+// LogArgs return Args with the sensitive arguments masked by Redact
+func (s *Statement) LogArgs() []any {
+	if len(s.sensitive) == 0 {
+		return s.Args
+	}
+	args := make([]any, len(s.Args))
+	for i, arg := range s.Args {
+		if _, have := s.sensitive[i]; have {
+			arg = Redact(arg)
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// unmask unwraps the sensitive arguments into a copy of Args, remembers their indexes
+func (s *Statement) unmask() {
+	args := make([]any, len(s.Args))
+	for i, arg := range s.Args {
+		if sa, ok := arg.(*sensitiveArg); ok {
+			if s.sensitive == nil {
+				s.sensitive = make(map[int]struct{}, 0)
+			}
+			s.sensitive[i] = struct{}{}
+			arg = sa.arg
+		}
+		args[i] = arg
+	}
+	s.Args = args
+}
+
+// unmaskArgs return the arguments with the sensitive arguments unwrapped
+func unmaskArgs(ps []any) []any {
+	stmt := &Statement{Args: ps}
+	stmt.unmask()
+	return stmt.Args
+}
+
+// logArgs return the arguments with the sensitive arguments masked by Redact
+func logArgs(ps []any) []any {
+	stmt := &Statement{Args: ps}
+	stmt.unmask()
+	return stmt.LogArgs()
+}
+
+// sensitiveValue marks the value sensitive if the entity column is sensitive
+func sensitiveValue(entityPkgName, column string, val any) any {
+	if _, have := entitySensitiveMap[entityPkgName][column]; have {
+		return Sensitive(val)
+	}
+	return val
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-the-way/sg"
+)
+
+type testRedactE struct {
+	ID       int    `orm:"pk{T} c{id}"`
+	Name     string `orm:"c{name}"`
+	Password string `orm:"c{password} sensitive{T}"`
+	Token    string `orm:"c{token}"`
+}
+
+func (t *testRedactE) Configure(c *EC) {
+	c.SensitiveColumns = []sg.C{"token"}
+}
+
+func init() {
+	Register(new(testRedactE))
+}
+
+func TestStatement_LogArgs(t *testing.T) {
+	ps := []any{1, Sensitive(Sensitive("secret")), "name"}
+	stmt := &Statement{Args: ps}
+	stmt.unmask()
+	if !reflect.DeepEqual(stmt.Args, []any{1, "secret", "name"}) {
+		t.Error("test failed")
+	}
+	if !reflect.DeepEqual(stmt.LogArgs(), []any{1, "******", "name"}) {
+		t.Error("test failed")
+	}
+	// the caller's args keep masked
+	if _, ok := ps[1].(*sensitiveArg); !ok {
+		t.Error("test failed")
+	}
+	if !reflect.DeepEqual(logArgs(ps), []any{1, "******", "name"}) {
+		t.Error("test failed")
+	}
+}
+
+func TestRedact(t *testing.T) {
+	logged := ""
+	Logger.SetLogLevel(LogLevelDebug)
+	Logger.SetHandler(LogHandlerFunc(func(level LogLevel, fields []*LogF, message string) {
+		for _, f := range fields {
+			if f.Key() == "Parameter" {
+				logged = fmt.Sprintf("%v", f.Value())
+			}
+		}
+	}))
+	defer Logger.SetHandler(nil)
+	e := &testRedactE{1, "coco", "123456", "abcdef"}
+	for _, tc := range []struct {
+		testName string
+		build    func() (string, []any)
+		args     []any
+		expect   string
+	}{
+		{"Insert", func() (string, []any) { return newInsertOperation(New(new(testRedactE))).getInsertBuilder(e) }, []any{1, "coco", "123456", "abcdef"}, "[1 coco ****** ******]"},
+		{"Update", func() (string, []any) { return newUpdateOperation(New(new(testRedactE))).getUpdateBuilder(e) }, []any{"coco", "123456", "abcdef", 1}, "[coco ****** ****** 1]"},
+		{"Delete", func() (string, []any) { return newsDeleteOperation(New(new(testRedactE))).getDeleteBuilder(e) }, []any{int64(1), "coco", "123456", "abcdef"}, "[1 coco ****** ******]"},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			r := &testRunner{}
+			sqlStr, ps := tc.build()
+			if _, err := ExecStatement(context.Background(), r, &Statement{Name: tc.testName, SQL: sqlStr, Args: ps}); err != nil {
+				t.Fatalf("TestRedact failed: %v\n", err)
+			}
+			if !reflect.DeepEqual(r.args, tc.args) {
+				t.Error("test failed")
+			}
+			if logged != tc.expect {
+				t.Error("test failed")
+			}
+		})
+	}
+}
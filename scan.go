@@ -0,0 +1,341 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"container/list"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ColumnMode defines how the columns without field are scanned
+type ColumnMode int
+
+const (
+	// ColumnStrict defines return an error naming the column without field
+	ColumnStrict ColumnMode = iota
+	// ColumnLenient defines discard the columns without field
+	ColumnLenient
+)
+
+var (
+	// ScanTag defines the struct tag the columns mapped by, e.g. `db:"user_name"`, `db:"-"` skips the field
+	ScanTag = "db"
+
+	ormColumnRe  = regexp.MustCompile(`(?:^|\s)c{([^{}]+)}`)
+	scannerType  = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+	errScanField = func(column string, rt reflect.Type) error {
+		return errors.New(fmt.Sprintf("anorm: column[%s] has no field in %v", column, rt))
+	}
+)
+
+// columnPathsKey defines the key of columnPathsCache
+type columnPathsKey struct {
+	rt      reflect.Type
+	columns string
+	mode    ColumnMode
+}
+
+// columnPathsCacheSize defines the max column paths cached, the least recently used evicted
+const columnPathsCacheSize = 1024
+
+// columnPathsCache caches the column paths, K<columnPathsKey> V<[][]int>
+var columnPathsCache = newPathsCache(columnPathsCacheSize)
+
+// pathsCache defines a LRU cache of the column paths, bounded for the dynamic columns such as SELECT *
+type pathsCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[columnPathsKey]*list.Element
+}
+
+type pathsEntry struct {
+	key   columnPathsKey
+	paths [][]int
+}
+
+func newPathsCache(size int) *pathsCache {
+	return &pathsCache{size: size, ll: list.New(), items: make(map[columnPathsKey]*list.Element, 0)}
+}
+
+// Load return the cached paths of the key
+func (c *pathsCache) Load(key columnPathsKey) ([][]int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, have := c.items[key]; have {
+		c.ll.MoveToFront(el)
+		return el.Value.(*pathsEntry).paths, true
+	}
+	return nil, false
+}
+
+// Store cache the paths of the key, evict the least recently used when full
+func (c *pathsCache) Store(key columnPathsKey, paths [][]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, have := c.items[key]; have {
+		el.Value.(*pathsEntry).paths = paths
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&pathsEntry{key, paths})
+	if c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.items, el.Value.(*pathsEntry).key)
+	}
+}
+
+// rowScanner scans a row into a new value of the struct or scalar type
+type rowScanner struct {
+	typ   reflect.Type
+	ptr   bool
+	paths [][]int // the field index paths of the columns, nil for scalar
+}
+
+// Scan scan rows return []T, T is a struct, a pointer to struct or a scalar type
+//
+// The columns are mapped to the struct fields by, in order:
+//
+// - the ScanTag, e.g. `db:"user_name"`
+//
+// - the orm tag column, e.g. `orm:"c{user_name}"`
+//
+// - the field name, e.g. UserName
+//
+// - the field name of the naming strategies, e.g. user_name and userName
+//
+// Then the names above case-insensitively, e.g. USER_NAME for databases upper-case the columns.
+// The fields of embedded structs are promoted, a column without field is scanned by the mode, default ColumnStrict.
+// A scalar T, sql.Scanner or time.Time, scans the only column
+func Scan[T any](rows *sql.Rows, mode ...ColumnMode) ([]T, error) {
+	ts := make([]T, 0)
+	if err := EachScan(rows, func(t T) error {
+		ts = append(ts, t)
+		return nil
+	}, mode...); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// EachScan scan rows one by one like Scan, call fn with each T
+//
+// The rows are closed when returned, a non-nil error returned by fn stops the scan and is returned
+func EachScan[T any](rows *sql.Rows, fn func(t T) error, mode ...ColumnMode) error {
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	s, err := newRowScanner(reflect.TypeOf((*T)(nil)).Elem(), columns, getColumnMode(mode))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		v, err2 := s.scan(rows)
+		if err2 != nil {
+			return err2
+		}
+		if err2 = fn(v.Interface().(T)); err2 != nil {
+			return err2
+		}
+	}
+	return rows.Err()
+}
+
+// ListAs select like List, scan into []T instead of []E, see Scan
+//
+// The columns are named by the entity field names, e.g. Name, CreateTime
+func ListAs[E Entity, T any](op SelectOperation[E], e E) ([]T, error) {
+	o, ok := op.(*selectOperation[E])
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("anorm: list as unsupported operation %T", op))
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	sqlStr, ps := o.getListBuilder(e)
+	rows, err := o.orm.query("OpsForSelect.ListAs", sqlStr, ps)
+	if err != nil {
+		return nil, err
+	}
+	return Scan[T](rows, o.columnMode)
+}
+
+// getColumnMode return the first mode, default ColumnStrict
+func getColumnMode(mode []ColumnMode) ColumnMode {
+	if len(mode) > 0 {
+		return mode[0]
+	}
+	return ColumnStrict
+}
+
+func newRowScanner(rt reflect.Type, columns []string, mode ColumnMode) (*rowScanner, error) {
+	s := &rowScanner{typ: rt}
+	if rt.Kind() == reflect.Pointer && rt.Elem().Kind() == reflect.Struct {
+		s.typ, s.ptr = rt.Elem(), true
+	}
+	if s.typ.Kind() != reflect.Struct || s.typ == timeType || reflect.PointerTo(s.typ).Implements(scannerType) {
+		if len(columns) != 1 {
+			return nil, errors.New(fmt.Sprintf("anorm: scan %v requires one column, got %d", rt, len(columns)))
+		}
+		return &rowScanner{typ: rt}, nil
+	}
+	paths, err := getColumnPaths(s.typ, columns, mode)
+	if err != nil {
+		return nil, err
+	}
+	s.paths = paths
+	return s, nil
+}
+
+// ptrs return the scan destinations of the struct pointer v
+func (s *rowScanner) ptrs(v reflect.Value) []any {
+	return newPathPtrs(v.Elem(), s.paths)
+}
+
+// newPathPtrs return the field pointers of the struct value by the paths, a discard pointer for nil path
+func newPathPtrs(structVal reflect.Value, paths [][]int) []any {
+	pts := make([]any, len(paths))
+	for i, path := range paths {
+		switch len(path) {
+		case 0:
+			pts[i] = new(any)
+		case 1:
+			pts[i] = structVal.Field(path[0]).Addr().Interface()
+		default:
+			pts[i] = structVal.FieldByIndex(path).Addr().Interface()
+		}
+	}
+	return pts
+}
+
+// getColumnPaths return the field index paths of the columns, nil path for the discarded column
+//
+// The paths are computed once per struct type, columns and mode, then cached, see columnPathsCacheSize
+func getColumnPaths(rt reflect.Type, columns []string, mode ColumnMode) ([][]int, error) {
+	key := columnPathsKey{rt, strings.Join(columns, "\x00"), mode}
+	if paths, have := columnPathsCache.Load(key); have {
+		return paths, nil
+	}
+	paths, err := newColumnPaths(rt, columns, mode)
+	if err != nil {
+		return nil, err
+	}
+	columnPathsCache.Store(key, paths)
+	return paths, nil
+}
+
+func newColumnPaths(rt reflect.Type, columns []string, mode ColumnMode) ([][]int, error) {
+	fields, foldFields := getScanFields(rt)
+	paths := make([][]int, len(columns))
+	for i, c := range columns {
+		path, have := fields[c]
+		if !have {
+			path, have = foldFields[strings.ToLower(c)]
+		}
+		if !have && mode == ColumnStrict {
+			return nil, errScanField(c, rt)
+		}
+		paths[i] = path
+	}
+	return paths, nil
+}
+
+// scan return the value of the type scanned from the current row
+func (s *rowScanner) scan(rows *sql.Rows) (reflect.Value, error) {
+	v := reflect.New(s.typ)
+	if s.paths == nil {
+		return v.Elem(), rows.Scan(v.Interface())
+	}
+	if err := rows.Scan(s.ptrs(v)...); err != nil {
+		return v, err
+	}
+	if s.ptr {
+		return v, nil
+	}
+	return v.Elem(), nil
+}
+
+// getScanFields return the field index paths of the struct keyed by the column names, and keyed by the lower-case names
+func getScanFields(rt reflect.Type) (map[string][]int, map[string][]int) {
+	type candidate struct {
+		path     []int
+		priority int
+	}
+	candidates := make(map[string]*candidate, 0)
+	add := func(name string, path []int, priority int) {
+		if c, have := candidates[name]; name != "" && (!have || priority < c.priority) {
+			candidates[name] = &candidate{path, priority}
+		}
+	}
+	var walk func(rt reflect.Type, index []int, depth int)
+	walk = func(rt reflect.Type, index []int, depth int) {
+		for i := 0; i < rt.NumField(); i++ {
+			sf := rt.Field(i)
+			path := append(append(make([]int, 0, len(index)+1), index...), i)
+			name, tagged := sf.Tag.Lookup(ScanTag)
+			if name == "-" {
+				continue
+			}
+			if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+				walk(sf.Type, path, depth+1)
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			// the outer fields win the embedded fields
+			priority := depth * 4
+			add(name, path, priority)
+			if ms := ormColumnRe.FindStringSubmatch(sf.Tag.Get("orm")); len(ms) == 2 {
+				add(ms[1], path, priority+1)
+			}
+			add(sf.Name, path, priority+2)
+			add(getStrategyName(sf.Name, Underline), path, priority+3)
+			add(getStrategyName(sf.Name, CamelCase), path, priority+3)
+		}
+	}
+	walk(rt, nil, 0)
+	fields := make(map[string][]int, len(candidates))
+	foldCandidates := make(map[string]*candidate, 0)
+	for name, c := range candidates {
+		fields[name] = c.path
+		// the higher priority wins, then the former field, keep it stable
+		if fc, have := foldCandidates[strings.ToLower(name)]; !have || c.priority < fc.priority || (c.priority == fc.priority && lessPath(c.path, fc.path)) {
+			foldCandidates[strings.ToLower(name)] = c
+		}
+	}
+	foldFields := make(map[string][]int, len(foldCandidates))
+	for name, c := range foldCandidates {
+		foldFields[name] = c.path
+	}
+	return fields, foldFields
+}
+
+func lessPath(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+type (
+	testScanBase struct {
+		ID   int
+		Name string
+	}
+	testScanDTO struct {
+		testScanBase
+		Name       string `db:"user_name"`
+		Age        int    `orm:"c{user_age}"`
+		CreateTime string
+		Ignored    string `db:"-"`
+		unexported string
+	}
+)
+
+func TestGetScanFields(t *testing.T) {
+	fields, foldFields := getScanFields(reflect.TypeOf(testScanDTO{}))
+	expect := map[string][]int{
+		"ID":          {0, 0},
+		"id":          {0, 0},
+		"user_name":   {1},
+		"Name":        {1},
+		"name":        {1},
+		"user_age":    {2},
+		"Age":         {2},
+		"age":         {2},
+		"CreateTime":  {3},
+		"create_time": {3},
+		"createTime":  {3},
+	}
+	if !reflect.DeepEqual(fields, expect) {
+		t.Fatalf("TestGetScanFields failed: %v\n", fields)
+	}
+	if !reflect.DeepEqual(foldFields["user_name"], []int{1}) || !reflect.DeepEqual(foldFields["createtime"], []int{3}) || !reflect.DeepEqual(foldFields["id"], []int{0, 0}) {
+		t.Fatalf("TestGetScanFields failed: %v\n", foldFields)
+	}
+}
+
+func TestGetColumnPaths(t *testing.T) {
+	rt := reflect.TypeOf(testScanDTO{})
+	if paths, err := getColumnPaths(rt, []string{"USER_NAME", "Create_Time", "age"}, ColumnStrict); err != nil || !reflect.DeepEqual(paths, [][]int{{1}, {3}, {2}}) {
+		t.Fatal("TestGetColumnPaths failed!")
+	}
+	if _, err := getColumnPaths(rt, []string{"id", "unknown"}, ColumnStrict); err == nil || err.Error() != "anorm: column[unknown] has no field in anorm.testScanDTO" {
+		t.Fatal("TestGetColumnPaths failed!")
+	}
+	if paths, err := getColumnPaths(rt, []string{"id", "unknown"}, ColumnLenient); err != nil || !reflect.DeepEqual(paths, [][]int{{0, 0}, nil}) {
+		t.Fatal("TestGetColumnPaths failed!")
+	}
+}
+
+func TestScan(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	type dto struct {
+		UserID   int    `db:"id"`
+		UserName string `db:"name"`
+		Age      int
+	}
+	rows, err := testDB.Query("select id, name, age from user_entity")
+	if err != nil {
+		t.Fatalf("TestScan failed: %v\n", err)
+	}
+	if ds, err := Scan[*dto](rows); err != nil {
+		t.Fatalf("TestScan failed: %v\n", err)
+	} else if len(ds) != 1 || ds[0].UserID != 1 || ds[0].UserName != testName || ds[0].Age != testAge {
+		t.Fatal("TestScan failed!")
+	}
+	rows, err = testDB.Query("select count(0) from user_entity")
+	if err != nil {
+		t.Fatalf("TestScan failed: %v\n", err)
+	}
+	if cs, err := Scan[int64](rows); err != nil || !reflect.DeepEqual(cs, []int64{1}) {
+		t.Fatal("TestScan failed!")
+	}
+	rows, err = testDB.Query("select id, phone from user_entity")
+	if err != nil {
+		t.Fatalf("TestScan failed: %v\n", err)
+	}
+	if _, err = Scan[dto](rows); err == nil {
+		t.Fatal("TestScan failed!")
+	}
+}
+
+func TestListAs(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	type dto struct {
+		ID   int
+		Name string
+	}
+	if ds, err := ListAs[*userEntity, dto](Select(new(userEntity)).Columns("ID", "Name"), nil); err != nil {
+		t.Fatalf("TestListAs failed: %v\n", err)
+	} else if !reflect.DeepEqual(ds, []dto{{1, testName}}) {
+		t.Fatal("TestListAs failed!")
+	}
+	if _, err := ListAs[*userEntity, dto](Select(new(userEntity)).Columns("ID", "Name", "Age"), nil); err == nil {
+		t.Fatal("TestListAs failed!")
+	}
+	if ds, err := ListAs[*userEntity, dto](Select(new(userEntity)).Columns("ID", "Name", "Age").ColumnMode(ColumnLenient), nil); err != nil {
+		t.Fatalf("TestListAs failed: %v\n", err)
+	} else if !reflect.DeepEqual(ds, []dto{{1, testName}}) {
+		t.Fatal("TestListAs failed!")
+	}
+}
+
+func TestGetColumnPathsCache(t *testing.T) {
+	rt := reflect.TypeOf(testScanDTO{})
+	paths, _ := getColumnPaths(rt, []string{"id", "user_name"}, ColumnStrict)
+	if cached, have := columnPathsCache.Load(columnPathsKey{rt, "id\x00user_name", ColumnStrict}); !have || !reflect.DeepEqual(cached, paths) {
+		t.Fatal("TestGetColumnPathsCache failed!")
+	}
+	if _, have := columnPathsCache.Load(columnPathsKey{rt, "id\x00user_name", ColumnLenient}); have {
+		t.Fatal("TestGetColumnPathsCache failed!")
+	}
+}
+
+func TestPathsCacheEvict(t *testing.T) {
+	c := newPathsCache(2)
+	k1, k2, k3 := columnPathsKey{columns: "a"}, columnPathsKey{columns: "b"}, columnPathsKey{columns: "c"}
+	c.Store(k1, [][]int{{1}})
+	c.Store(k2, [][]int{{2}})
+	// k1 used recently, k2 evicted
+	if _, have := c.Load(k1); !have {
+		t.Fatal("TestPathsCacheEvict failed!")
+	}
+	c.Store(k3, [][]int{{3}})
+	if _, have := c.Load(k2); have || c.ll.Len() != 2 || len(c.items) != 2 {
+		t.Fatal("TestPathsCacheEvict failed!")
+	}
+	if paths, have := c.Load(k1); !have || !reflect.DeepEqual(paths, [][]int{{1}}) {
+		t.Fatal("TestPathsCacheEvict failed!")
+	}
+	if paths, have := c.Load(k3); !have || !reflect.DeepEqual(paths, [][]int{{3}}) {
+		t.Fatal("TestPathsCacheEvict failed!")
+	}
+}
+
+var benchmarkColumns = []string{"ID", "Name", "Age", "Address", "Phone", "CreateTime"}
+
+// newColumnPtrByName the NewColumnPtr before the paths cached, FieldByName per column of every row
+func newColumnPtrByName(structVal reflect.Value, columns []string) []any {
+	pts := make([]any, len(columns))
+	for i, c := range columns {
+		pts[i] = structVal.Elem().FieldByName(c).Addr().Interface()
+	}
+	return pts
+}
+
+// BenchmarkNewColumnPtrByName the scan destinations per row before the paths cached
+func BenchmarkNewColumnPtrByName(b *testing.B) {
+	rt := reflect.TypeOf(userEntity{})
+	for i := 0; i < b.N; i++ {
+		_ = newColumnPtrByName(reflect.New(rt), benchmarkColumns)
+	}
+}
+
+// BenchmarkNewColumnPtr the scan destinations per row by the cached paths
+func BenchmarkNewColumnPtr(b *testing.B) {
+	rt := reflect.TypeOf(userEntity{})
+	for i := 0; i < b.N; i++ {
+		_ = NewColumnPtr(reflect.New(rt), benchmarkColumns)
+	}
+}
+
+// BenchmarkRowScannerPtrs the scan destinations per row of ScanStruct, the paths resolved once per rows
+func BenchmarkRowScannerPtrs(b *testing.B) {
+	s, _ := newRowScanner(reflect.TypeOf(new(userEntity)), benchmarkColumns, ColumnStrict)
+	for i := 0; i < b.N; i++ {
+		_ = s.ptrs(reflect.New(s.typ))
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidRequest defines the request can not be parsed, the errors returned by the parse functions wrap it
+	ErrInvalidRequest = errors.New("search: invalid request")
+
+	timeType       = reflect.TypeOf(time.Time{})
+	unmarshalType  = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	errInvalidFunc = func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidRequest, fmt.Sprintf(format, args...))
+	}
+)
+
+// Request defines the standard search request
+//
+// Query params: ?page=2&size=20&sort=-createTime,name&name=coco&status=1&status=2
+//
+// JSON body: {"page": 2, "size": 20, "sort": "-createTime,name", "name": "coco", "status": [1, 2]}
+type Request struct {
+	Page int    `json:"page"` // the page number, from 1
+	Size int    `json:"size"` // the page size
+	Sort string `json:"sort"` // the comma separated sort fields, prefix - for DESC
+	// Filter defines the filter struct pointer declared by the anorm.FilterTag, decoded from the same params
+	Filter any `json:"-"`
+}
+
+// FromValues parse the request from the query params, the filter fields are decoded by the json names
+//
+// The repeated params or the comma separated values are decoded into the slice fields
+func FromValues(values url.Values, filter any) (*Request, error) {
+	req := &Request{Sort: values.Get("sort"), Filter: filter}
+	var err error
+	if req.Page, err = parseInt(values, "page"); err != nil {
+		return nil, err
+	}
+	if req.Size, err = parseInt(values, "size"); err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		if err = decodeValues(values, filter); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// FromJSON parse the request from the JSON body, the filter fields are decoded from the same object
+func FromJSON(r io.Reader, filter any) (*Request, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	req := &Request{Filter: filter}
+	if len(bytes.TrimSpace(bs)) <= 0 {
+		return req, nil
+	}
+	if err = json.Unmarshal(bs, req); err != nil {
+		return nil, errInvalidFunc("%v", err)
+	}
+	if filter != nil {
+		if err = json.Unmarshal(bs, filter); err != nil {
+			return nil, errInvalidFunc("%v", err)
+		}
+	}
+	return req, nil
+}
+
+// FromHTTP parse the request from the JSON body if the content type is application/json, or else the query params
+func FromHTTP(r *http.Request, filter any) (*Request, error) {
+	if r.Body != nil && r.Method != http.MethodGet {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+			return FromJSON(r.Body, filter)
+		}
+	}
+	return FromValues(r.URL.Query(), filter)
+}
+
+func parseInt(values url.Values, name string) (int, error) {
+	s := strings.TrimSpace(values.Get(name))
+	if s == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errInvalidFunc("param [%s] must be an integer", name)
+	}
+	return i, nil
+}
+
+// decodeValues decode the query params into the struct fields by the json names, the embedded structs promoted
+func decodeValues(values url.Values, dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("search: filter must be a pointer to struct, got %T", dest)
+	}
+	return decodeStruct(values, rv.Elem())
+}
+
+func decodeStruct(values url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			if err := decodeStruct(values, rv.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		ss, have := values[name]
+		if !have || len(ss) <= 0 {
+			continue
+		}
+		if err := setValue(rv.Field(i), ss); err != nil {
+			return errInvalidFunc("param [%s] %v", name, err)
+		}
+	}
+	return nil
+}
+
+func setValue(value reflect.Value, ss []string) error {
+	if value.Kind() == reflect.Ptr {
+		v := reflect.New(value.Type().Elem())
+		if err := setValue(v.Elem(), ss); err != nil {
+			return err
+		}
+		value.Set(v)
+		return nil
+	}
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		parts := make([]string, 0, len(ss))
+		for _, s := range ss {
+			for _, p := range strings.Split(s, ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					parts = append(parts, p)
+				}
+			}
+		}
+		slice := reflect.MakeSlice(value.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setValue(slice.Index(i), []string{p}); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+		return nil
+	}
+	s := strings.TrimSpace(ss[0])
+	if s == "" {
+		return nil
+	}
+	if value.Type() == timeType {
+		t, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if value.CanAddr() && value.Addr().Type().Implements(unmarshalType) {
+		bs, _ := json.Marshal(s)
+		return value.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(bs)
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %v", value.Type())
+	}
+	return nil
+}
+
+// parseTime parse the RFC3339 time, the datetime or the date in local
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	testPage struct {
+		Keyword string `json:"keyword"`
+	}
+	testFilter struct {
+		testPage
+		Name    string    `json:"name"`
+		MinAge  *int      `json:"minAge"`
+		Status  []int     `json:"status"`
+		Active  bool      `json:"active"`
+		Since   time.Time `json:"since"`
+		Ignored string    `json:"-"`
+	}
+)
+
+func TestFromValues(t *testing.T) {
+	{
+		values, _ := url.ParseQuery("page=2&size=10&sort=-id&keyword=k&name=coco&minAge=0&status=1,2&status=3&active=true&since=2022-01-02&Ignored=x")
+		filter := &testFilter{}
+		req, err := FromValues(values, filter)
+		if err != nil {
+			t.Fatalf("test failed! %v", err)
+		}
+		if req.Page != 2 || req.Size != 10 || req.Sort != "-id" || req.Filter != filter {
+			t.Fatal("test failed!")
+		}
+		if filter.Keyword != "k" || filter.Name != "coco" || filter.MinAge == nil || *filter.MinAge != 0 ||
+			!reflect.DeepEqual(filter.Status, []int{1, 2, 3}) || !filter.Active || filter.Since.Day() != 2 || filter.Ignored != "" {
+			t.Fatalf("test failed! %+v", filter)
+		}
+	}
+	{
+		if _, err := FromValues(url.Values{"page": {"x"}}, nil); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatal("test failed!")
+		}
+		if _, err := FromValues(url.Values{"status": {"1,x"}}, &testFilter{}); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	{
+		filter := &testFilter{}
+		req, err := FromJSON(strings.NewReader(`{"page": 2, "size": 10, "sort": "name", "name": "coco", "status": [1, 2]}`), filter)
+		if err != nil || req.Page != 2 || req.Size != 10 || req.Sort != "name" || filter.Name != "coco" || !reflect.DeepEqual(filter.Status, []int{1, 2}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		if req, err := FromJSON(strings.NewReader(" "), nil); err != nil || req.Page != 0 {
+			t.Fatal("test failed!")
+		}
+		if _, err := FromJSON(strings.NewReader(`{"page": "x"}`), nil); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestFromHTTP(t *testing.T) {
+	{
+		r, _ := http.NewRequest(http.MethodGet, "/users?page=3&name=coco", nil)
+		filter := &testFilter{}
+		if req, err := FromHTTP(r, filter); err != nil || req.Page != 3 || filter.Name != "coco" {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		r, _ := http.NewRequest(http.MethodPost, "/users/search?page=3", strings.NewReader(`{"page": 4, "name": "coco"}`))
+		r.Header.Set("Content-Type", "application/json; charset=utf-8")
+		filter := &testFilter{}
+		if req, err := FromHTTP(r, filter); err != nil || req.Page != 4 || filter.Name != "coco" {
+			t.Fatal("test failed!")
+		}
+	}
+}
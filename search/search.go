@@ -0,0 +1,139 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"github.com/go-the-way/anorm"
+	"github.com/go-the-way/anorm/pagination"
+	"math"
+)
+
+// DefaultOptions defines the options used if nil passed
+var DefaultOptions = &Options{DefaultSize: 20, MaxSize: 100}
+
+type (
+	// Options defines how the request applied
+	Options struct {
+		DefaultSize int              // the size if the request size is not positive
+		MaxSize     int              // the max size, no limit if not positive
+		DefaultSort string           // the sort if the request sort is empty
+		Sorts       []string         // the sort fields whitelist, all registered fields allowed if empty
+		Pager       pagination.Pager // the pager, the datasource dialect of the operation if nil
+	}
+	// Result defines the page result envelope
+	Result[E any] struct {
+		Items   []E   `json:"items"`
+		Total   int64 `json:"total"`
+		Page    int   `json:"page"`
+		Size    int   `json:"size"`
+		Count   int   `json:"count"` // the page count
+		HasNext bool  `json:"hasNext"`
+	}
+)
+
+// Apply append the filter wheres and the sort OrderBys of the request to op
+//
+// The unknown or not allowed sort fields return an error wraps ErrInvalidRequest
+func Apply[E anorm.Entity](op anorm.SelectOperation[E], e E, req *Request, opts *Options) (anorm.SelectOperation[E], error) {
+	opts = getOptions(opts)
+	if req.Filter != nil {
+		wheres, err := anorm.FilterWheres(req.Filter)
+		if err != nil {
+			return nil, err
+		}
+		op.Where(wheres...)
+	}
+	sort := req.Sort
+	if sort == "" {
+		sort = opts.DefaultSort
+	}
+	fields, err := anorm.ParseSort(e, sort, opts.Sorts...)
+	if err != nil {
+		return nil, errInvalidFunc("%v", err)
+	}
+	return op.OrderByField(fields...), nil
+}
+
+// Page apply the request to op and select for the page, see Apply
+//
+// Params:
+//
+// - op: the select operation, e.g. anorm.Select(e).Join()
+//
+// - e: the orm wrapper entity
+//
+// - req: the search request
+//
+// - opts: the options, DefaultOptions if nil
+//
+// Returns:
+//
+// - result: the page result envelope
+//
+// - err: the invalid request or exec error
+func Page[E anorm.Entity](op anorm.SelectOperation[E], e E, req *Request, opts *Options) (*Result[E], error) {
+	opts = getOptions(opts)
+	op, err := Apply(op, e, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	page, size := getPageSize(req, opts)
+	es, total, err := op.Page(e, opts.Pager, (page-1)*size, size)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(es, total, page, size), nil
+}
+
+func getOptions(opts *Options) *Options {
+	if opts == nil {
+		return DefaultOptions
+	}
+	return opts
+}
+
+// getPageSize return the normalized page from 1 and the size limited by the options
+//
+// The size is at least 1, the page is capped so the offset never overflows
+func getPageSize(req *Request, opts *Options) (page, size int) {
+	page, size = req.Page, req.Size
+	if page < 1 {
+		page = 1
+	}
+	if size <= 0 {
+		size = opts.DefaultSize
+	}
+	if size <= 0 {
+		size = DefaultOptions.DefaultSize
+	}
+	if opts.MaxSize > 0 && size > opts.MaxSize {
+		size = opts.MaxSize
+	}
+	if size < 1 {
+		size = 1
+	}
+	if maxPage := math.MaxInt / size; page > maxPage {
+		page = maxPage
+	}
+	return
+}
+
+func newResult[E any](es []E, total int64, page, size int) *Result[E] {
+	if es == nil {
+		es = make([]E, 0)
+	}
+	if size < 1 {
+		size = 1
+	}
+	count := int((total + int64(size) - 1) / int64(size))
+	return &Result[E]{Items: es, Total: total, Page: page, Size: size, Count: count, HasNext: page < count}
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-the-way/anorm"
+)
+
+type (
+	testUser struct {
+		ID         int    `orm:"pk{T} c{id}"`
+		Name       string `orm:"c{name}"`
+		Age        int    `orm:"c{age}"`
+		CreateTime string `orm:"c{create_time}"`
+	}
+	testUserFilter struct {
+		Name   string `json:"name" q:"like,name"`
+		MinAge *int   `json:"minAge" q:"gte,age"`
+		IDs    []int  `json:"ids" q:"in,id"`
+	}
+)
+
+func (u *testUser) Configure(c *anorm.EC) {
+	c.Table = "search_user"
+}
+
+func init() {
+	if tdb, err := sql.Open("mysql", os.Getenv("ANORM_TEST_DSN")+"?parseTime=true"); err != nil {
+		panic(err)
+	} else {
+		anorm.DataSourcePool.Push(tdb)
+	}
+	anorm.Register(new(testUser))
+}
+
+func TestApply(t *testing.T) {
+	{
+		minAge := 0
+		req := &Request{Sort: "-createTime,name", Filter: &testUserFilter{Name: "co", MinAge: &minAge, IDs: []int{1, 2}}}
+		op, err := Apply(anorm.Select(new(testUser)), new(testUser), req, nil)
+		if err != nil {
+			t.Fatalf("test failed! %v", err)
+		}
+		sqlStr, ps := op.ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID, t.name AS Name, t.age AS Age, t.create_time AS CreateTime FROM search_user AS t WHERE ((name LIKE CONCAT('%', ?, '%')) AND (age >= ?) AND (id IN (?, ?))) ORDER BY t.create_time DESC, t.name ASC" || len(ps) != 4 {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		op, _ := Apply(anorm.Select(new(testUser)).Columns("ID"), new(testUser), &Request{}, &Options{DefaultSort: "-id"})
+		if sqlStr, _ := op.ToSQL(nil); sqlStr != "SELECT t.id AS ID FROM search_user AS t ORDER BY t.id DESC" {
+			t.Fatalf("test failed! %s", sqlStr)
+		}
+	}
+	{
+		if _, err := Apply(anorm.Select(new(testUser)), new(testUser), &Request{Sort: "age"}, &Options{Sorts: []string{"id"}}); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatal("test failed!")
+		}
+		if _, err := Apply(anorm.Select(new(testUser)), new(testUser), &Request{Sort: "password"}, nil); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestGetPageSize(t *testing.T) {
+	for _, c := range []struct {
+		req        *Request
+		opts       *Options
+		page, size int
+	}{
+		{&Request{}, DefaultOptions, 1, 20},
+		{&Request{Page: 3, Size: 500}, DefaultOptions, 3, 100},
+		{&Request{Page: -1, Size: 500}, &Options{}, 1, 500},
+		{&Request{Page: 2}, &Options{DefaultSize: 5}, 2, 5},
+		{&Request{Page: math.MaxInt, Size: 10}, DefaultOptions, math.MaxInt / 10, 10},
+	} {
+		if page, size := getPageSize(c.req, c.opts); page != c.page || size != c.size {
+			t.Fatalf("test failed! %d %d", page, size)
+		}
+	}
+	defaultSize := DefaultOptions.DefaultSize
+	defer func() { DefaultOptions.DefaultSize = defaultSize }()
+	DefaultOptions.DefaultSize = 0
+	if page, size := getPageSize(&Request{}, &Options{}); page != 1 || size != 1 {
+		t.Fatalf("test failed! %d %d", page, size)
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	if r := newResult[int](nil, 0, 1, 20); r.Items == nil || r.Count != 0 || r.HasNext {
+		t.Fatal("test failed!")
+	}
+	if r := newResult([]int{1, 2}, 41, 2, 20); r.Count != 3 || !r.HasNext {
+		t.Fatal("test failed!")
+	}
+	if r := newResult([]int{1}, 41, 3, 20); r.Count != 3 || r.HasNext {
+		t.Fatal("test failed!")
+	}
+	if r := newResult([]int{1}, 2, 1, 0); r.Size != 1 || r.Count != 2 || !r.HasNext {
+		t.Fatal("test failed!")
+	}
+}
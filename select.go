@@ -12,29 +12,65 @@
 package anorm
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/go-the-way/anorm/pagination"
 	"github.com/go-the-way/sg"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 type (
 	SelectOperation[E Entity] interface {
 		BeginTx(txm *TxManager, options ...*sql.TxOptions) error
-		CountJoin() SelectOperation[E]
-		Join() SelectOperation[E]
+		WithContext(ctx context.Context) SelectOperation[E]
+		CountJoin(fields ...string) SelectOperation[E]
+		Join(fields ...string) SelectOperation[E]
+		Columns(fields ...string) SelectOperation[E]
+		Omit(fields ...string) SelectOperation[E]
+		ColumnMode(mode ColumnMode) SelectOperation[E]
+		MatchFields(fields ...string) SelectOperation[E]
+		Distinct() SelectOperation[E]
+		Limit(n int) SelectOperation[E]
+		Offset(n int) SelectOperation[E]
 		IfWhere(cond bool, wheres ...sg.Ge) SelectOperation[E]
 		Where(wheres ...sg.Ge) SelectOperation[E]
+		Filter(dto any) SelectOperation[E]
 		OrderBy(orderBys ...sg.Ge) SelectOperation[E]
+		OrderByField(fields ...string) SelectOperation[E]
 		One(ie E) (oe E, err error)
 		List(e E) (es []E, err error)
+		Each(e E, fn func(e E) error) error
+		Chunk(e E, size int, fn func(es []E) error) error
+		ChunkAfter(e E, size int, after []any, fn func(es []E) error) error
+		ChunkTx(e E, size int, after []any, fn func(txm *TxManager, es []E) error) error
+		First(e E) (E, error)
+		Exists(e E) (bool, error)
+		ExistsByPK(e E) (bool, error)
+		Last(e E) (E, error)
 		Page(e E, pager pagination.Pager, offset, size int) (es []E, total int64, err error)
+		PageWithoutCount(e E, pager pagination.Pager, offset, size int) (es []E, hasNext bool, err error)
+		PageEstimated(e E, pager pagination.Pager, offset, size int) (es []E, estimated int64, err error)
+		CursorPage(e E, cursor string, size int, keys ...string) (es []E, next, prev string, err error)
+		ToSQL(e E) (sqlStr string, ps []any)
+		PageToSQL(e E, pager pagination.Pager, offset, size int) (countSQL string, countPs []any, sqlStr string, ps []any)
+		Explain(e E) (*Plan, error)
+		PageExplain(e E, pager pagination.Pager, offset, size int) (*Plan, error)
 	}
 	selectOperation[E Entity] struct {
 		orm                       *Orm[E]
-		countJoin, join           bool
+		countJoin, join, distinct bool
+		limit, offset             int
 		columns, wheres, orderBys []sg.Ge
+		selectFields, omitFields  map[string]struct{}
+		matchFields               map[string]struct{}
+		joinFields                map[string]struct{}
+		countJoinFields           map[string]struct{}
+		columnMode                ColumnMode
+		err                       error // the first builder error, returned by the executions
 	}
 )
 
@@ -47,25 +83,156 @@ func SelectWithDs[E Entity](e E, ds string) SelectOperation[E] {
 }
 
 func newSelectOperation[E Entity](o *Orm[E]) *selectOperation[E] {
-	return &selectOperation[E]{orm: o, columns: make([]sg.Ge, 0), wheres: make([]sg.Ge, 0), orderBys: make([]sg.Ge, 0)}
+	return &selectOperation[E]{orm: o, columns: make([]sg.Ge, 0), wheres: make([]sg.Ge, 0), orderBys: make([]sg.Ge, 0), selectFields: make(map[string]struct{}, 0), omitFields: make(map[string]struct{}, 0)}
 }
 
 func (o *selectOperation[E]) BeginTx(txm *TxManager, options ...*sql.TxOptions) error {
 	return o.orm.BeginTx(txm, options...)
 }
 
-// CountJoin enable join query
-func (o *selectOperation[E]) CountJoin() SelectOperation[E] {
+// WithContext set the context passed to the entity hooks and the interceptors
+func (o *selectOperation[E]) WithContext(ctx context.Context) SelectOperation[E] {
+	o.orm.WithContext(ctx)
+	return o
+}
+
+// CountJoin enable join query of Page count, only the join fields if listed, or else the joins of Join
+//
+// The fields not the join fields fail the executions with an error
+func (o *selectOperation[E]) CountJoin(fields ...string) SelectOperation[E] {
 	o.countJoin = true
+	o.countJoinFields = o.getJoinFieldMap(o.countJoinFields, fields...)
 	return o
 }
 
-// Join enable join query
-func (o *selectOperation[E]) Join() SelectOperation[E] {
+// Join enable join query, only the join fields if listed, or else all the join fields, accepts field or column names
+//
+// The fields not the join fields fail the executions with an error
+func (o *selectOperation[E]) Join(fields ...string) SelectOperation[E] {
 	o.join = true
+	o.joinFields = o.getJoinFieldMap(o.joinFields, fields...)
+	return o
+}
+
+func (o *selectOperation[E]) getJoinFieldMap(joinFields map[string]struct{}, fields ...string) map[string]struct{} {
+	if joinFields == nil && len(fields) > 0 {
+		joinFields = make(map[string]struct{}, len(fields))
+	}
+	joinRefMap := entityJoinRefMap[getEntityPkgName(o.orm.entity)]
+	for _, f := range fields {
+		fieldName := o.getFieldName(f)
+		if _, have := joinRefMap[fieldName]; !have {
+			o.setErr(errUnknownJoinField(o.orm.entity, f))
+			continue
+		}
+		joinFields[fieldName] = struct{}{}
+	}
+	return joinFields
+}
+
+// joined return true if the join field is listed, or none listed
+func joined(joinFields map[string]struct{}, fieldName string) bool {
+	_, have := joinFields[fieldName]
+	return have || len(joinFields) <= 0
+}
+
+// Columns select only the fields, accepts field or column names, the unselected fields left zero value
+//
+// The unknown fields fail the executions with an error
+func (o *selectOperation[E]) Columns(fields ...string) SelectOperation[E] {
+	for _, f := range fields {
+		if fieldName, have := o.getEntityField(f); have {
+			o.selectFields[fieldName] = struct{}{}
+		}
+	}
+	return o
+}
+
+// Omit select all fields except the fields, accepts field or column names, the omitted fields left zero value
+//
+// The unknown fields fail the executions with an error
+func (o *selectOperation[E]) Omit(fields ...string) SelectOperation[E] {
+	for _, f := range fields {
+		if fieldName, have := o.getEntityField(f); have {
+			o.omitFields[fieldName] = struct{}{}
+		}
+	}
+	return o
+}
+
+// ColumnMode set how List, Each, Page and ListAs scan the columns without field, default ColumnStrict
+func (o *selectOperation[E]) ColumnMode(mode ColumnMode) SelectOperation[E] {
+	o.columnMode = mode
+	return o
+}
+
+// getEntityField return the field name of the registered field or column name, join fields included,
+// or else keep the unknown field error
+func (o *selectOperation[E]) getEntityField(name string) (string, bool) {
+	fieldName := o.getFieldName(name)
+	if _, have := entityFieldColumnMap[getEntityPkgName(o.orm.entity)][fieldName]; have {
+		return fieldName, true
+	}
+	if _, have := entityJoinRefMap[getEntityPkgName(o.orm.entity)][fieldName]; have {
+		return fieldName, true
+	}
+	o.setErr(errUnknownField(o.orm.entity, name))
+	return "", false
+}
+
+// setErr keep the first builder error
+func (o *selectOperation[E]) setErr(err error) {
+	if o.err == nil {
+		o.err = err
+	}
+}
+
+// MatchFields match the given fields even when they hold zero values, accepts field or column names,
+// a nil pointer field matches IS NULL
+//
+// The unknown fields fail the executions with an error
+func (o *selectOperation[E]) MatchFields(fields ...string) SelectOperation[E] {
+	matchFields, err := o.orm.getMatchFields(o.matchFields, fields...)
+	o.matchFields = matchFields
+	o.setErr(err)
 	return o
 }
 
+// Distinct select distinct rows
+func (o *selectOperation[E]) Distinct() SelectOperation[E] {
+	o.distinct = true
+	return o
+}
+
+// Limit select at most n rows through the datasource dialect, applied to List and One
+func (o *selectOperation[E]) Limit(n int) SelectOperation[E] {
+	o.limit = n
+	return o
+}
+
+// Offset skip n rows through the datasource dialect, applied to List and One
+func (o *selectOperation[E]) Offset(n int) SelectOperation[E] {
+	o.offset = n
+	return o
+}
+
+// getFieldName return the field name of the field or column name
+func (o *selectOperation[E]) getFieldName(name string) string {
+	if fieldName, have := entityColumnFieldMap[getEntityPkgName(o.orm.entity)][name]; have {
+		return fieldName
+	}
+	return name
+}
+
+// selected return true if the field is selected by Columns and not omitted by Omit
+func (o *selectOperation[E]) selected(fieldName string) bool {
+	if _, have := o.selectFields[fieldName]; len(o.selectFields) > 0 && !have {
+		return false
+	}
+	_, omitted := o.omitFields[fieldName]
+	return !omitted
+}
+
 func (o *selectOperation[E]) getColumns() []sg.Ge {
 	columnGes := make([]sg.Ge, 0)
 	columns, cHave := entityColumnMap[getEntityPkgName(o.orm.entity)]
@@ -82,7 +249,7 @@ func (o *selectOperation[E]) getColumns() []sg.Ge {
 	if cHave {
 		for _, c := range columns {
 			fieldName, fieldHave := entityColumnFieldMap[getEntityPkgName(o.orm.entity)][c]
-			if fieldHave && (!jHave || joinRefMap[fieldName] == nil) {
+			if fieldHave && (!jHave || joinRefMap[fieldName] == nil) && o.selected(fieldName) {
 				// add: null function
 				if nullHave && nullFieldMap[fieldName] != nil {
 					jn := nullFieldMap[fieldName]
@@ -116,34 +283,76 @@ func (f *funcGe) SQL() (string, []interface{}) {
 	return f.define, f.args
 }
 
-func (o *selectOperation[E]) getJoinRef() ([]sg.Ge, []sg.Ge) {
+// getJoinFields return the sorted join fields, keep the SQL stable
+func (o *selectOperation[E]) getJoinFields() []string {
+	joinRefMap := entityJoinRefMap[getEntityPkgName(o.orm.entity)]
+	fields := make([]string, 0, len(joinRefMap))
+	for k := range joinRefMap {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// getRelAliasMap return the rel table alias map, e.g. rel_table => rel1
+func (o *selectOperation[E]) getRelAliasMap() map[string]string {
+	refTableMap := make(map[string]string, 0)
+	joinRefMap := entityJoinRefMap[getEntityPkgName(o.orm.entity)]
+	for _, k := range o.getJoinFields() {
+		if v := joinRefMap[k]; refTableMap[v.RelTable] == "" {
+			refTableMap[v.RelTable] = fmt.Sprintf("rel%d", len(refTableMap)+1)
+		}
+	}
+	return refTableMap
+}
+
+// getFieldColumn return the column of the field or column name, e.g. t.name or rel1.name for the join field
+func (o *selectOperation[E]) getFieldColumn(name string) (sg.C, bool) {
+	fieldName := o.getFieldName(name)
+	if jr, have := entityJoinRefMap[getEntityPkgName(o.orm.entity)][fieldName]; have {
+		if !o.join || !joined(o.joinFields, fieldName) {
+			return "", false
+		}
+		return sg.C(o.getRelAliasMap()[jr.RelTable] + "." + jr.RelName), true
+	}
+	if column, have := entityFieldColumnMap[getEntityPkgName(o.orm.entity)][fieldName]; have {
+		return sg.C("t." + column), true
+	}
+	return "", false
+}
+
+func (o *selectOperation[E]) getJoinRef(joinFields map[string]struct{}) ([]sg.Ge, []sg.Ge) {
 	columnGes := make([]sg.Ge, 0)
 	joinGs := make([]sg.Ge, 0)
-	refCount := 1
-	refTableMap := make(map[string]string, 0)
+	joinedMap := make(map[string]struct{}, 0)
 	if joinRefMap, have := entityJoinRefMap[getEntityPkgName(o.orm.entity)]; have && o.join {
 		nullFieldMap, nullHave := entityJoinNullFieldMap[getEntityPkgName(o.orm.entity)]
+		refTableMap := o.getRelAliasMap()
 		// append join column
-		for k, v := range joinRefMap {
-			relAlias, joined := refTableMap[v.RelTable]
-			if relAlias == "" {
-				relAlias = fmt.Sprintf("rel%d", refCount)
-				refTableMap[v.RelTable] = relAlias
-				refCount++
+		for _, k := range o.getJoinFields() {
+			if !joined(joinFields, k) {
+				continue
 			}
+			v := joinRefMap[k]
+			relAlias := refTableMap[v.RelTable]
+			_, joined := joinedMap[v.RelTable]
+			joinedMap[v.RelTable] = struct{}{}
 
-			// add: null function
-			if nullHave && nullFieldMap[k] != nil {
-				jn := nullFieldMap[k]
-				// IFNULL(rel1.name, 'defaultVal') AS alias
-				if jn.DefaultArg {
-					columnGes = append(columnGes, sg.Alias(newFuncGe(fmt.Sprintf("%s(%s, ?)", jn.FuncName, relAlias+"."+v.RelName), jn.DefaultVal), k))
+			// the unselected field keeps the join, the wheres may reference the rel alias
+			if o.selected(k) {
+				// add: null function
+				if nullHave && nullFieldMap[k] != nil {
+					jn := nullFieldMap[k]
+					// IFNULL(rel1.name, 'defaultVal') AS alias
+					if jn.DefaultArg {
+						columnGes = append(columnGes, sg.Alias(newFuncGe(fmt.Sprintf("%s(%s, ?)", jn.FuncName, relAlias+"."+v.RelName), jn.DefaultVal), k))
+					} else {
+						columnGes = append(columnGes, sg.Alias(newFuncGe(fmt.Sprintf("%s(%s, %v)", jn.FuncName, relAlias+"."+v.RelName, jn.DefaultVal)), k))
+					}
 				} else {
-					columnGes = append(columnGes, sg.Alias(newFuncGe(fmt.Sprintf("%s(%s, %v)", jn.FuncName, relAlias+"."+v.RelName, jn.DefaultVal)), k))
+					// rel_table.rel_column AS RelColumn
+					columnGes = append(columnGes, sg.Alias(sg.C(relAlias+"."+v.RelName), k))
 				}
-			} else {
-				// rel_table.rel_column AS RelColumn
-				columnGes = append(columnGes, sg.Alias(sg.C(relAlias+"."+v.RelName), k))
 			}
 
 			// LEFT JOIN rel_table ON rel_table.rel_id = t.self_id
@@ -182,18 +391,169 @@ func (o *selectOperation[E]) Where(wheres ...sg.Ge) SelectOperation[E] {
 	return o
 }
 
+// Wheres return the wheres appended to op, e.g. apply the scope of a select to the update or delete
+//
+// The builder error of op is returned, such as the Filter error
+func Wheres[E Entity](op SelectOperation[E]) ([]sg.Ge, error) {
+	o, ok := op.(*selectOperation[E])
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("anorm: wheres unsupported operation %T", op))
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	return append(make([]sg.Ge, 0, len(o.wheres)), o.wheres...), nil
+}
+
 // OrderBy append OrderBys
 func (o *selectOperation[E]) OrderBy(orderBys ...sg.Ge) SelectOperation[E] {
 	o.orderBys = append(o.orderBys, orderBys...)
 	return o
 }
 
-func (o *selectOperation[E]) appendWhereGes(entity E) {
-	o.wheres = append(o.wheres, o.orm.getWhereGes(entity)...)
+// OrderByField append OrderBys of the fields, accepts field or column names, prefix - for DESC, e.g. -CreateTime, Name
+//
+// The unknown fields and the join fields without Join called before fail the executions with an error,
+// see ParseSort for the user-supplied fields
+func (o *selectOperation[E]) OrderByField(fields ...string) SelectOperation[E] {
+	for _, f := range fields {
+		desc := strings.HasPrefix(f, "-")
+		name := strings.TrimPrefix(f, "-")
+		if _, have := o.getEntityField(name); !have {
+			continue
+		}
+		column, have := o.getFieldColumn(name)
+		if !have {
+			o.setErr(errOrderByNotJoined(name))
+			continue
+		}
+		if desc {
+			o.orderBys = append(o.orderBys, sg.Desc(column))
+		} else {
+			o.orderBys = append(o.orderBys, sg.Asc(column))
+		}
+	}
+	return o
+}
+
+func (o *selectOperation[E]) getWheres(entity E) []sg.Ge {
+	wheres := make([]sg.Ge, 0, len(o.wheres))
+	wheres = append(wheres, o.wheres...)
+	return append(wheres, o.orm.getWhereGes(entity, o.matchFields)...)
+}
+
+func (o *selectOperation[E]) getSelectBuilder(entity E) (string, []any) {
+	refColumns, refJoins := o.getJoinRef(o.joinFields)
+	columns := append(o.getColumns(), refColumns...)
+	if o.distinct {
+		// SELECT DISTINCT t.id AS ID, ...
+		columns = []sg.Ge{sg.NewJoiner(columns, ", ", "DISTINCT ", "", false)}
+	}
+	selectBuilder := sg.SelectBuilder().
+		Select(columns...).
+		From(sg.Alias(o.getTableName(), "t")).
+		Where(sg.AndGroup(o.getWheres(entity)...)).
+		OrderBy(o.orderBys...)
+	if len(refJoins) > 0 {
+		selectBuilder.Join(sg.NewJoiner(refJoins, " ", "", "", false))
+	}
+	return selectBuilder.Build()
+}
+
+func (o *selectOperation[E]) getListBuilder(entity E) (string, []any) {
+	sqlStr, ps := o.getSelectBuilder(entity)
+	if o.limit <= 0 && o.offset <= 0 {
+		return sqlStr, ps
+	}
+	sqlStr, lps := DataSourcePool.Dialect(o.orm.ds).Limit(sqlStr, o.offset, o.limit)
+	return sqlStr, append(ps, lps...)
+}
+
+func (o *selectOperation[E]) getPageBuilder(entity E, pager pagination.Pager, offset, size int) (string, []any) {
+	sqlStr, ps := o.getSelectBuilder(entity)
+	if pager == nil {
+		pager = DataSourcePool.Dialect(o.orm.ds)
+	}
+	sqlStr, pps := pager.Page(sqlStr, offset, size)
+	return sqlStr, append(ps, pps...)
+}
+
+func (o *selectOperation[E]) getCountOperation() *selectCountOperation[E] {
+	sc := newSelectCountOperation(o.orm)
+	sc.Where(o.wheres...)
+	sc.matchFields = o.matchFields
+	if o.countJoin {
+		joinFields := o.countJoinFields
+		if len(joinFields) <= 0 {
+			joinFields = o.joinFields
+		}
+		if _, refJoins := o.getJoinRef(joinFields); len(refJoins) > 0 {
+			sc.Join(sg.NewJoiner(refJoins, " ", "", "", false))
+		}
+	}
+	if o.distinct {
+		// count the distinct rows of the page select, the order is meaningless
+		sc.subquery = func(entity E) (string, []any) {
+			po := *o
+			po.orderBys = nil
+			return po.getSelectBuilder(entity)
+		}
+	}
+	return sc
+}
+
+// ToSQL return the SQL and arguments List would execute, without touching the datasource
+//
+// It returns an empty SQL if the operation has a builder error
+func (o *selectOperation[E]) ToSQL(e E) (sqlStr string, ps []any) {
+	if o.err != nil {
+		return "", nil
+	}
+	sqlStr, ps = o.getListBuilder(e)
+	return sqlStr, unmaskArgs(ps)
+}
+
+// PageToSQL return the count and page SQL and arguments Page would execute, without touching the datasource
+func (o *selectOperation[E]) PageToSQL(e E, pager pagination.Pager, offset, size int) (countSQL string, countPs []any, sqlStr string, ps []any) {
+	if o.err != nil {
+		return "", nil, "", nil
+	}
+	countSQL, countPs = o.getCountOperation().ToSQL(e)
+	sqlStr, ps = o.getPageBuilder(e, pager, offset, size)
+	return countSQL, countPs, sqlStr, unmaskArgs(ps)
+}
+
+// Explain run the datasource dialect's EXPLAIN on the statement List would execute
+func (o *selectOperation[E]) Explain(e E) (*Plan, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	sqlStr, ps := o.getListBuilder(e)
+	return o.orm.explain("OpsForSelect.List", sqlStr, ps)
+}
+
+// PageExplain run the datasource dialect's EXPLAIN on the page statement Page would execute
+func (o *selectOperation[E]) PageExplain(e E, pager pagination.Pager, offset, size int) (*Plan, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	sqlStr, ps := o.getPageBuilder(e, pager, offset, size)
+	return o.orm.explain("OpsForSelect.Page", sqlStr, ps)
 }
 
 var (
 	ErrSelectTooManyResult = errors.New("query one return too many result")
+	errCursorMixedOrder    = errors.New("anorm: cursor keys must be ordered in the same direction")
+	errChunkSize           = errors.New("anorm: chunk size must be positive")
+	errCursorSize          = errors.New("anorm: cursor page size must be positive")
+	errPageSize            = errors.New("anorm: page size must be positive")
+	errChunkAfter          = errors.New("anorm: chunk after must be the values of the primary keys")
+	errUnknownJoinField    = func(entity EntityConfigurator, field string) error {
+		return errors.New(fmt.Sprintf("anorm: unknown join field [%s] of entity [%v]", field, getEntityPkgName(entity)))
+	}
+	errOrderByNotJoined = func(field string) error {
+		return errors.New(fmt.Sprintf("anorm: order by join field [%s] requires Join called before", field))
+	}
 )
 
 // One select for one return
@@ -219,6 +579,73 @@ func (o *selectOperation[E]) One(ie E) (oe E, err error) {
 	return
 }
 
+// Exists return true if any entity matches the wheres and the entity
+func (o *selectOperation[E]) Exists(e E) (bool, error) {
+	return o.exists("OpsForSelect.Exists", o.getWheres(e))
+}
+
+// ExistsByPK return true if the entity of the primary keys exists, the wheres are ignored
+func (o *selectOperation[E]) ExistsByPK(e E) (bool, error) {
+	wheres, err := o.orm.getPKWhereGes(e)
+	if err != nil {
+		return false, err
+	}
+	return o.exists("OpsForSelect.ExistsByPK", wheres)
+}
+
+func (o *selectOperation[E]) exists(name string, wheres []sg.Ge) (exists bool, err error) {
+	if o.err != nil {
+		return false, o.err
+	}
+	selectBuilder := sg.SelectBuilder().
+		Select(sg.C("1")).
+		From(sg.Alias(o.getTableName(), "t")).
+		Where(sg.AndGroup(wheres...))
+	if _, refJoins := o.getJoinRef(o.joinFields); len(refJoins) > 0 {
+		selectBuilder.Join(sg.NewJoiner(refJoins, " ", "", "", false))
+	}
+	sqlStr, ps := selectBuilder.Build()
+	var row *sql.Row
+	if row, err = o.orm.queryRow(name, DataSourcePool.Dialect(o.orm.ds).Exists(sqlStr), ps); err != nil {
+		return
+	}
+	err = row.Scan(&exists)
+	return
+}
+
+// First select the first entity ordered by the primary keys, the OrderBy is replaced
+//
+// Return zero value if no entity found
+func (o *selectOperation[E]) First(e E) (E, error) {
+	return o.firstOrLast(e, sg.Asc)
+}
+
+// Last select the last entity ordered by the primary keys, the OrderBy is replaced
+//
+// Return zero value if no entity found
+func (o *selectOperation[E]) Last(e E) (E, error) {
+	return o.firstOrLast(e, sg.Desc)
+}
+
+func (o *selectOperation[E]) firstOrLast(e E, order func(g sg.Ge) sg.Ge) (oe E, err error) {
+	pks := entityPKMap[getEntityPkgName(o.orm.entity)]
+	if len(pks) <= 0 {
+		return oe, errEntityNoPK(o.orm.entity)
+	}
+	op := *o
+	op.orderBys = make([]sg.Ge, 0, len(pks))
+	for _, pk := range pks {
+		op.orderBys = append(op.orderBys, order(sg.C("t."+pk)))
+	}
+	op.limit, op.offset = 1, 0
+	if es, err2 := op.List(e); err2 != nil {
+		err = err2
+	} else if len(es) > 0 {
+		oe = es[0]
+	}
+	return
+}
+
 // List select for entities
 //
 // Params:
@@ -232,27 +659,131 @@ func (o *selectOperation[E]) One(ie E) (oe E, err error) {
 // - err: exec error
 //
 func (o *selectOperation[E]) List(e E) (es []E, err error) {
-	o.appendWhereGes(e)
-	selectBuilder := sg.SelectBuilder().
-		Select(o.getColumns()...).
-		From(sg.Alias(o.getTableName(), "t")).
-		Where(sg.AndGroup(o.wheres...)).
-		OrderBy(o.orderBys...)
-	refColumns, refJoins := o.getJoinRef()
-	if len(refColumns) > 0 {
-		selectBuilder.Select(refColumns...)
+	if o.err != nil {
+		return nil, o.err
 	}
-	if len(refJoins) > 0 {
-		selectBuilder.Join(sg.NewJoiner(refJoins, " ", "", "", false))
-	}
-	sqlStr, ps := selectBuilder.Build()
-	queryLog("OpsForSelect.List", sqlStr, ps)
+	sqlStr, ps := o.getListBuilder(e)
 	var rows *sql.Rows
-	if rows, err = o.orm.db.Query(sqlStr, ps...); err != nil {
-		queryErrorLog(err, "OpsForSelect.List", sqlStr, ps)
+	if rows, err = o.orm.query("OpsForSelect.List", sqlStr, ps); err != nil {
 		return
 	}
-	return ScanStruct(rows, o.orm.entity, entityComplete[getEntityPkgName(e)])
+	if es, err = ScanStruct(rows, o.orm.entity, entityComplete[getEntityPkgName(e)], o.columnMode); err != nil {
+		return nil, err
+	}
+	if err = o.orm.afterFind(es); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Each select for entities, scan and call fn one by one without loading all entities
+//
+// Params:
+//
+// - e: the orm wrapper entity
+//
+// - fn: called with each entity, a non-nil error stops the iteration
+//
+// Returns:
+//
+// - err: exec error or the error returned by fn
+func (o *selectOperation[E]) Each(e E, fn func(e E) error) error {
+	if o.err != nil {
+		return o.err
+	}
+	sqlStr, ps := o.getListBuilder(e)
+	rows, err := o.orm.query("OpsForSelect.Each", sqlStr, ps)
+	if err != nil {
+		return err
+	}
+	return EachStruct(rows, o.orm.entity, entityComplete[getEntityPkgName(e)], func(e E) error {
+		if err2 := o.orm.afterFind([]E{e}); err2 != nil {
+			return err2
+		}
+		return fn(e)
+	}, o.columnMode)
+}
+
+// Chunk select for entities in batches ordered by the primary keys, see ChunkAfter
+func (o *selectOperation[E]) Chunk(e E, size int, fn func(es []E) error) error {
+	return o.ChunkAfter(e, size, nil, fn)
+}
+
+// ChunkAfter select for entities in batches ordered by the primary keys, the OrderBy is replaced
+//
+// Params:
+//
+// - e: the orm wrapper entity
+//
+// - size: the batch size
+//
+// - after: resume after the primary key values, nil from the start, e.g. the key of the last entity processed before a crash
+//
+// - fn: called with each batch, a non-nil error stops the iteration
+//
+// Returns:
+//
+// - err: exec error or the error returned by fn
+func (o *selectOperation[E]) ChunkAfter(e E, size int, after []any, fn func(es []E) error) error {
+	return o.chunk(e, size, after, fn)
+}
+
+// ChunkTx select for entities in batches like ChunkAfter, each batch processed in its own TxManager
+//
+// The operations joined the TxManager by BeginTx are committed if fn returns nil, or rolled back
+func (o *selectOperation[E]) ChunkTx(e E, size int, after []any, fn func(txm *TxManager, es []E) error) error {
+	return o.chunk(e, size, after, func(es []E) error {
+		txm := NewTxManager()
+		if err := fn(txm, es); err != nil {
+			_ = txm.Rollback()
+			return err
+		}
+		return txm.Commit()
+	})
+}
+
+func (o *selectOperation[E]) chunk(e E, size int, after []any, fn func(es []E) error) error {
+	pks := entityPKMap[getEntityPkgName(o.orm.entity)]
+	if len(pks) <= 0 {
+		return errEntityNoPK(o.orm.entity)
+	}
+	if size <= 0 {
+		return errChunkSize
+	}
+	if after != nil && len(after) != len(pks) {
+		return errChunkAfter
+	}
+	columns, fields := make([]string, 0, len(pks)), make([]string, 0, len(pks))
+	orderBys := make([]sg.Ge, 0, len(pks))
+	for _, pk := range pks {
+		columns = append(columns, "t."+pk)
+		fields = append(fields, entityColumnFieldMap[getEntityPkgName(o.orm.entity)][pk])
+		orderBys = append(orderBys, sg.Asc(sg.C("t."+pk)))
+	}
+	for {
+		po := *o
+		po.wheres = append(make([]sg.Ge, 0, len(o.wheres)+1), o.wheres...)
+		if after != nil {
+			seekSQL, seekPs := DataSourcePool.Dialect(o.orm.ds).Seek(columns, after, false)
+			po.wheres = append(po.wheres, newFuncGe(seekSQL, seekPs...))
+		}
+		po.orderBys = orderBys
+		po.limit, po.offset = size, 0
+		es, err := po.List(e)
+		if err != nil {
+			return err
+		}
+		if len(es) <= 0 {
+			return nil
+		}
+		if err = fn(es); err != nil {
+			return err
+		}
+		if len(es) < size {
+			return nil
+		}
+		after = getFieldValues(es[len(es)-1], fields)
+	}
 }
 
 // Page select for page
@@ -261,7 +792,7 @@ func (o *selectOperation[E]) List(e E) (es []E, err error) {
 //
 // - e: the orm wrapper entity
 //
-// - pager: the pager see pkg pagination
+// - pager: the pager see pkg pagination, the datasource dialect if nil
 //
 // - offset: start index
 //
@@ -276,39 +807,233 @@ func (o *selectOperation[E]) List(e E) (es []E, err error) {
 // - err: exec error
 //
 func (o *selectOperation[E]) Page(e E, pager pagination.Pager, offset, size int) (es []E, total int64, err error) {
-	refColumns, refJoins := o.getJoinRef()
-	sc := o.orm.OpsForSelectCount()
-	sc.Where(o.wheres...)
-	if o.countJoin && len(refJoins) > 0 {
-		sc.Join(sg.NewJoiner(refJoins, " ", "", "", false))
+	if o.err != nil {
+		return nil, 0, o.err
 	}
-	total, err = sc.Count(e)
+	total, err = o.getCountOperation().Count(e)
 	if err != nil {
 		return
 	}
 	if total <= 0 {
 		return make([]E, 0), 0, nil
 	}
-	selectBuilder := sg.SelectBuilder().
-		Select(o.getColumns()...).
-		From(sg.Alias(o.getTableName(), "t")).
-		Where(sg.AndGroup(o.wheres...)).
-		OrderBy(o.orderBys...)
-	if len(refColumns) > 0 {
-		selectBuilder.Select(refColumns...)
+	if es, err = o.pageList(e, pager, offset, size); err != nil {
+		return nil, 0, err
 	}
-	if len(refJoins) > 0 {
-		selectBuilder.Join(sg.NewJoiner(refJoins, " ", "", "", false))
+	return
+}
+
+// PageWithoutCount select for page without the count query, fetches size+1 rows to check the next page
+//
+// Params:
+//
+// - e: the orm wrapper entity
+//
+// - pager: the pager see pkg pagination, the datasource dialect if nil
+//
+// - offset: start index
+//
+// - size: select size, must be positive
+//
+// Returns:
+//
+// - entities: entities
+//
+// - hasNext: has the next page
+//
+// - err: exec error
+func (o *selectOperation[E]) PageWithoutCount(e E, pager pagination.Pager, offset, size int) (es []E, hasNext bool, err error) {
+	if size <= 0 {
+		return nil, false, errPageSize
 	}
-	sqlStr, ps := selectBuilder.Build()
-	sqlStr, pps := pager.Page(sqlStr, offset, size)
-	ps = append(ps, pps...)
-	queryLog("OpsForSelect.Page", sqlStr, ps)
+	if es, err = o.pageList(e, pager, offset, size+1); err != nil {
+		return nil, false, err
+	}
+	if hasNext = len(es) > size; hasNext {
+		es = es[:size]
+	}
+	return
+}
+
+// PageEstimated select for page with the total estimated by the datasource dialect's EXPLAIN instead of the count query
+//
+// Params:
+//
+// - e: the orm wrapper entity
+//
+// - pager: the pager see pkg pagination, the datasource dialect if nil
+//
+// - offset: start index
+//
+// - size: select size
+//
+// Returns:
+//
+// - entities: entities
+//
+// - estimated: estimated total rows size, not less than offset plus the entities size
+//
+// - err: exec error
+func (o *selectOperation[E]) PageEstimated(e E, pager pagination.Pager, offset, size int) (es []E, estimated int64, err error) {
+	if o.err != nil {
+		return nil, 0, o.err
+	}
+	var plan *Plan
+	sqlStr, ps := o.getSelectBuilder(e)
+	if plan, err = o.orm.explain("OpsForSelect.Page", sqlStr, ps); err != nil {
+		return
+	}
+	estimated = DataSourcePool.Dialect(o.orm.ds).Estimate(plan.Rows)
+	if es, err = o.pageList(e, pager, offset, size); err != nil {
+		return nil, 0, err
+	}
+	if fetched := int64(offset + len(es)); estimated < fetched {
+		estimated = fetched
+	}
+	return
+}
+
+func (o *selectOperation[E]) pageList(e E, pager pagination.Pager, offset, size int) (es []E, err error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	sqlStr, ps := o.getPageBuilder(e, pager, offset, size)
 	var rows *sql.Rows
-	if rows, err = o.orm.db.Query(sqlStr, ps...); err != nil {
-		queryErrorLog(err, "OpsForSelect.Page", sqlStr, ps)
+	if rows, err = o.orm.query("OpsForSelect.Page", sqlStr, ps); err != nil {
 		return
 	}
-	es, err = ScanStruct(rows, o.orm.entity, entityComplete[getEntityPkgName(e)])
+	if es, err = ScanStruct(rows, o.orm.entity, entityComplete[getEntityPkgName(e)], o.columnMode); err != nil {
+		return nil, err
+	}
+	if err = o.orm.afterFind(es); err != nil {
+		return nil, err
+	}
 	return
 }
+
+// CursorPage select for keyset page, ordered by the keys, the OrderBy is replaced
+//
+// Params:
+//
+// - e: the orm wrapper entity
+//
+// - cursor: the cursor token returned by the previous call, empty for the first page
+//
+// - size: the page size, must be positive
+//
+// - keys: the unique key fields or columns, default the primary keys, prefix - for DESC, e.g. -CreateTime, -ID
+//
+// Returns:
+//
+// - entities: entities
+//
+// - next: the next page cursor, empty if no next page
+//
+// - prev: the previous page cursor, empty if no previous page
+//
+// - err: exec error
+func (o *selectOperation[E]) CursorPage(e E, cursor string, size int, keys ...string) (es []E, next, prev string, err error) {
+	if size <= 0 {
+		return nil, "", "", errCursorSize
+	}
+	if len(keys) <= 0 {
+		keys = entityPKMap[getEntityPkgName(o.orm.entity)]
+		if len(keys) <= 0 {
+			return nil, "", "", errEntityNoPK(o.orm.entity)
+		}
+	}
+	columns, fields := make([]string, 0, len(keys)), make([]string, 0, len(keys))
+	desc := strings.HasPrefix(keys[0], "-")
+	for _, k := range keys {
+		if strings.HasPrefix(k, "-") != desc {
+			return nil, "", "", errCursorMixedOrder
+		}
+		k = strings.TrimPrefix(k, "-")
+		column, have := o.getFieldColumn(k)
+		if !have {
+			return nil, "", "", errUnknownField(o.orm.entity, k)
+		}
+		columns = append(columns, string(column))
+		fields = append(fields, o.getFieldName(k))
+	}
+	c := &pagination.Cursor{}
+	if cursor != "" {
+		if c, err = pagination.DecodeCursor(cursor); err != nil {
+			return
+		}
+		if c.Values, err = o.getCursorValues(c, fields); err != nil {
+			return
+		}
+	}
+	// fetch the rows before the cursor in reverse order
+	reverse := desc != c.Before
+	po := *o
+	po.wheres = append(make([]sg.Ge, 0, len(o.wheres)+1), o.wheres...)
+	if len(c.Values) > 0 {
+		seekSQL, seekPs := DataSourcePool.Dialect(o.orm.ds).Seek(columns, c.Values, reverse)
+		po.wheres = append(po.wheres, newFuncGe(seekSQL, seekPs...))
+	}
+	po.orderBys = make([]sg.Ge, 0, len(columns))
+	for _, column := range columns {
+		if reverse {
+			po.orderBys = append(po.orderBys, sg.Desc(sg.C(column)))
+		} else {
+			po.orderBys = append(po.orderBys, sg.Asc(sg.C(column)))
+		}
+	}
+	po.limit, po.offset = size+1, 0
+	if es, err = po.List(e); err != nil {
+		return nil, "", "", err
+	}
+	more := len(es) > size
+	if more {
+		es = es[:size]
+	}
+	if c.Before {
+		for i, j := 0, len(es)-1; i < j; i, j = i+1, j-1 {
+			es[i], es[j] = es[j], es[i]
+		}
+	}
+	if len(es) <= 0 {
+		return
+	}
+	if more || c.Before {
+		next = o.getCursor(es[len(es)-1], fields, false)
+	}
+	if (c.Before && more) || (!c.Before && len(c.Values) > 0) {
+		prev = o.getCursor(es[0], fields, true)
+	}
+	return
+}
+
+// getCursorValues return the cursor values decoded as the types of the key fields
+func (o *selectOperation[E]) getCursorValues(c *pagination.Cursor, fields []string) ([]any, error) {
+	rt := reflect.TypeOf(o.orm.entity).Elem()
+	dests := make([]any, 0, len(fields))
+	for _, f := range fields {
+		sf, _ := rt.FieldByName(f)
+		dests = append(dests, reflect.New(sf.Type).Interface())
+	}
+	if err := c.Scan(dests...); err != nil {
+		return nil, err
+	}
+	values := make([]any, 0, len(dests))
+	for _, dest := range dests {
+		values = append(values, reflect.ValueOf(dest).Elem().Interface())
+	}
+	return values, nil
+}
+
+func (o *selectOperation[E]) getCursor(e E, fields []string, before bool) string {
+	return (&pagination.Cursor{Values: getFieldValues(e, fields), Before: before}).Encode()
+}
+
+// getFieldValues return the values of the entity fields
+func getFieldValues[E Entity](e E, fields []string) []any {
+	rv := reflect.ValueOf(e).Elem()
+	values := make([]any, 0, len(fields))
+	for _, f := range fields {
+		values = append(values, rv.FieldByName(f).Interface())
+	}
+	return values
+}
@@ -12,21 +12,30 @@
 package anorm
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"github.com/go-the-way/sg"
 )
 
 type (
 	SelectCountOperation[E Entity] interface {
 		BeginTx(txm *TxManager, options ...*sql.TxOptions) error
+		WithContext(ctx context.Context) SelectCountOperation[E]
 		IfWhere(cond bool, wheres ...sg.Ge) SelectCountOperation[E]
 		Where(wheres ...sg.Ge) SelectCountOperation[E]
 		Join(joins ...sg.Ge) SelectCountOperation[E]
+		MatchFields(fields ...string) SelectCountOperation[E]
 		Count(e E) (c int64, err error)
+		ToSQL(e E) (sqlStr string, ps []any)
 	}
 	selectCountOperation[E Entity] struct {
 		orm           *Orm[E]
 		wheres, joins []sg.Ge
+		matchFields   map[string]struct{}
+		err           error // the first builder error, returned by Count
+		// subquery defines count the rows of the subquery instead, e.g. the distinct select
+		subquery func(entity E) (string, []any)
 	}
 )
 
@@ -46,6 +55,12 @@ func (o *selectCountOperation[E]) BeginTx(txm *TxManager, options ...*sql.TxOpti
 	return o.orm.BeginTx(txm, options...)
 }
 
+// WithContext set the context passed to the entity hooks and the interceptors
+func (o *selectCountOperation[E]) WithContext(ctx context.Context) SelectCountOperation[E] {
+	o.orm.WithContext(ctx)
+	return o
+}
+
 // IfWhere if cond is true, append wheres
 func (o *selectCountOperation[E]) IfWhere(cond bool, wheres ...sg.Ge) SelectCountOperation[E] {
 	if cond {
@@ -64,12 +79,49 @@ func (o *selectCountOperation[E]) Join(joins ...sg.Ge) SelectCountOperation[E] {
 	return o
 }
 
+// MatchFields match the given fields even when they hold zero values, accepts field or column names,
+// a nil pointer field matches IS NULL
+//
+// The unknown fields fail Count with an error
+func (o *selectCountOperation[E]) MatchFields(fields ...string) SelectCountOperation[E] {
+	matchFields, err := o.orm.getMatchFields(o.matchFields, fields...)
+	o.matchFields = matchFields
+	if o.err == nil {
+		o.err = err
+	}
+	return o
+}
+
 func (o *selectCountOperation[E]) getTableName() sg.Ge {
 	return sg.T(entityTableMap[getEntityPkgName(o.orm.entity)])
 }
 
-func (o *selectCountOperation[E]) appendWhereGes(entity E) {
-	o.wheres = append(o.wheres, o.orm.getWhereGes(entity)...)
+func (o *selectCountOperation[E]) getWheres(entity E) []sg.Ge {
+	wheres := make([]sg.Ge, 0, len(o.wheres))
+	wheres = append(wheres, o.wheres...)
+	return append(wheres, o.orm.getWhereGes(entity, o.matchFields)...)
+}
+
+func (o *selectCountOperation[E]) getCountBuilder(entity E) (string, []any) {
+	if o.subquery != nil {
+		sqlStr, ps := o.subquery(entity)
+		return fmt.Sprintf("SELECT count(0) AS c FROM (%s) AS _t", sqlStr), ps
+	}
+	return sg.SelectBuilder().
+		Select(sg.Alias(sg.C("count(0)"), "c")).
+		From(sg.Alias(o.getTableName(), "t")).
+		Where(sg.AndGroup(o.getWheres(entity)...)).
+		Join(o.joins...).
+		Build()
+}
+
+// ToSQL return the SQL and arguments Count would execute, without touching the datasource
+func (o *selectCountOperation[E]) ToSQL(e E) (sqlStr string, ps []any) {
+	if o.err != nil {
+		return "", nil
+	}
+	sqlStr, ps = o.getCountBuilder(e)
+	return sqlStr, unmaskArgs(ps)
 }
 
 // Count select count
@@ -85,17 +137,12 @@ func (o *selectCountOperation[E]) appendWhereGes(entity E) {
 // - err: exec error
 //
 func (o *selectCountOperation[E]) Count(e E) (count int64, err error) {
-	o.appendWhereGes(e)
-	sqlStr, ps := sg.SelectBuilder().
-		Select(sg.Alias(sg.C("count(0)"), "c")).
-		From(sg.Alias(o.getTableName(), "t")).
-		Where(sg.AndGroup(o.wheres...)).
-		Join(o.joins...).
-		Build()
-	queryLog("OpsForSelectCount.Count", sqlStr, ps)
-	row := o.orm.db.QueryRow(sqlStr, ps...)
-	queryErrorLog(row.Err(), "OpsForSelectCount.Count", sqlStr, ps)
-	if err = row.Err(); err != nil {
+	if o.err != nil {
+		return 0, o.err
+	}
+	sqlStr, ps := o.getCountBuilder(e)
+	var row *sql.Row
+	if row, err = o.orm.queryRow("OpsForSelectCount.Count", sqlStr, ps); err != nil {
 		return
 	}
 	err = row.Scan(&count)
@@ -13,6 +13,7 @@ package anorm
 
 import (
 	"github.com/go-the-way/sg"
+	"reflect"
 	"testing"
 )
 
@@ -111,3 +112,10 @@ func TestSelectCountBeginTx(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestSelectCountToSQL(t *testing.T) {
+	sqlStr, ps := SelectCount(new(userEntity)).Where(sg.Eq("age", 9)).ToSQL(&userEntity{Name: "coco"})
+	if sqlStr != "SELECT count(0) AS c FROM user_entity AS t WHERE ((age = ?) AND (name = ?))" || !reflect.DeepEqual(ps, []any{9, "coco"}) {
+		t.Fatal("test failed!")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package anorm
+
+import (
+	"errors"
+	"iter"
+)
+
+var errIterStop = errors.New("anorm: iteration stopped")
+
+// Iter return an iterator over the entities selected by op, scanned one by one like Each
+//
+//	for e, err := range anorm.Iter(anorm.Select(new(User)), nil) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+//
+// Breaking the range closes the rows, an exec error is yielded with zero E as the last pair
+func Iter[E Entity](op SelectOperation[E], e E) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		err := op.Each(e, func(e E) error {
+			if !yield(e, nil) {
+				return errIterStop
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errIterStop) {
+			var zero E
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package anorm
+
+import (
+	"github.com/go-the-way/sg"
+	"testing"
+)
+
+func TestIter(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 3; i++ {
+		_ = insertTest()
+	}
+	count := 0
+	for e, err := range Iter(Select(new(userEntity)), nil) {
+		if err != nil {
+			t.Fatalf("TestIter failed: %v\n", err)
+		}
+		if e.Name != testName {
+			t.Fatal("TestIter failed!")
+		}
+		if count++; count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Fatal("TestIter failed!")
+	}
+	for _, err := range Iter(Select(new(userEntity)).Where(sg.Eq("xyz", 1)), nil) {
+		if err == nil {
+			t.Fatal("TestIter failed!")
+		}
+	}
+}
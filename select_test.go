@@ -12,10 +12,15 @@
 package anorm
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/go-the-way/anorm/pagination"
 	"github.com/go-the-way/sg"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -413,3 +418,521 @@ func TestSelectOneErrSelectTooManyResult(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestSelectToSQL(t *testing.T) {
+	e := &userEntity{ID: 1, Name: "coco"}
+	{
+		o := Select(new(userEntity)).Where(sg.Eq("age", 9)).OrderBy(sg.Desc(sg.C("id")))
+		sqlStr, ps := o.ToSQL(e)
+		if sqlStr != "SELECT t.id AS ID, t.name AS Name, t.age AS Age, t.address AS Address, t.phone AS Phone, t.create_time AS CreateTime FROM user_entity AS t WHERE ((age = ?) AND (id = ?) AND (name = ?)) ORDER BY id DESC" || !reflect.DeepEqual(ps, []any{9, int64(1), "coco"}) {
+			t.Fatal("test failed!")
+		}
+		// ToSQL does not change the operation
+		if sqlStr2, ps2 := o.ToSQL(e); sqlStr2 != sqlStr || !reflect.DeepEqual(ps2, ps) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		countSQL, countPs, sqlStr, ps := Select(new(userEntity)).Where(sg.Eq("age", 9)).PageToSQL(e, pagination.MySql, 0, 10)
+		if countSQL != "SELECT count(0) AS c FROM user_entity AS t WHERE ((age = ?) AND (id = ?) AND (name = ?))" || !reflect.DeepEqual(countPs, []any{9, int64(1), "coco"}) {
+			t.Fatal("test failed!")
+		}
+		if sqlStr != "SELECT t.id AS ID, t.name AS Name, t.age AS Age, t.address AS Address, t.phone AS Phone, t.create_time AS CreateTime FROM user_entity AS t WHERE ((age = ?) AND (id = ?) AND (name = ?)) LIMIT ?, ?" || !reflect.DeepEqual(ps, []any{9, int64(1), "coco", 0, 10}) {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestSelectExplain(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	if plan, err := Select(new(userEntity)).Explain(&userEntity{ID: 1}); err != nil {
+		t.Fatalf("TestSelectExplain failed: %v\n", err)
+	} else if len(plan.Rows) != 1 || plan.FullScan {
+		t.Fatal("TestSelectExplain failed!")
+	}
+	if plan, err := Select(new(userEntity)).PageExplain(&userEntity{}, pagination.MySql, 0, 10); err != nil {
+		t.Fatalf("TestSelectExplain failed: %v\n", err)
+	} else if len(plan.Rows) != 1 || !plan.FullScan {
+		t.Fatal("TestSelectExplain failed!")
+	}
+}
+
+func TestSelectColumnsToSQL(t *testing.T) {
+	{
+		sqlStr, _ := Select(new(userEntity)).Columns("ID", "name").ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID, t.name AS Name FROM user_entity AS t" {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, _ := Select(new(userEntity)).Omit("Address", "create_time").ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID, t.name AS Name, t.age AS Age, t.phone AS Phone FROM user_entity AS t" {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, _ := Select(new(userEntity)).Columns("ID", "Name").Omit("Name").ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t" {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		for _, o := range []SelectOperation[*userEntity]{Select(new(userEntity)).Columns("ID", "Bogus"), Select(new(userEntity)).Omit("bogus")} {
+			if sqlStr, _ := o.ToSQL(nil); sqlStr != "" {
+				t.Fatal("test failed!")
+			}
+			if _, err := o.List(nil); err == nil || !strings.Contains(err.Error(), "unknown field") {
+				t.Fatal("test failed!")
+			}
+			if _, _, err := o.Page(nil, pagination.MySql, 0, 10); err == nil {
+				t.Fatal("test failed!")
+			}
+		}
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	if es, err := Select(new(userEntity)).Columns("ID", "Name").List(nil); err != nil {
+		t.Fatalf("TestSelectColumns failed: %v\n", err)
+	} else if len(es) <= 0 || es[0].ID == 0 || es[0].Name == "" || es[0].Address != "" {
+		t.Fatal("TestSelectColumns failed!")
+	}
+}
+
+type _MatchPtr struct {
+	ID   int     `orm:"pk{T} c{id}"`
+	Name *string `orm:"c{name}"`
+	Age  *int    `orm:"c{age}"`
+}
+
+func (_ *_MatchPtr) Configure(c *EC) {
+	c.Table = "match_ptr"
+}
+
+func TestSelectMatchFieldsToSQL(t *testing.T) {
+	{
+		sqlStr, ps := Select(new(userEntity)).Columns("ID").MatchFields("Age").ToSQL(&userEntity{Name: testName})
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t WHERE ((name = ?) AND (age = ?))" || !reflect.DeepEqual(ps, []any{testName, 0}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		countSQL, countPs := SelectCount(new(userEntity)).MatchFields("phone").ToSQL(&userEntity{})
+		if countSQL != "SELECT count(0) AS c FROM user_entity AS t WHERE ((phone = ?))" || !reflect.DeepEqual(countPs, []any{""}) {
+			t.Fatalf("test failed! %s %v", countSQL, countPs)
+		}
+	}
+	{
+		if _, have := entityTableMap[getEntityPkgName(new(_MatchPtr))]; !have {
+			Register(new(_MatchPtr))
+		}
+		age, name, empty := 0, testName, ""
+		// the pointers out of MatchFields keep skipping the zero values
+		sqlStr, ps := Select(new(_MatchPtr)).Columns("ID").ToSQL(&_MatchPtr{Name: &empty, Age: &age})
+		if sqlStr != "SELECT t.id AS ID FROM match_ptr AS t" || len(ps) != 0 {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+		sqlStr, ps = Select(new(_MatchPtr)).Columns("ID").MatchFields("Age").ToSQL(&_MatchPtr{Name: &name, Age: &age})
+		if sqlStr != "SELECT t.id AS ID FROM match_ptr AS t WHERE ((name = ?) AND (age = ?))" || !reflect.DeepEqual(ps, []any{testName, 0}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+		sqlStr, ps = Delete(new(_MatchPtr)).MatchFields("age").ToSQL(&_MatchPtr{})
+		if sqlStr != "DELETE FROM match_ptr WHERE ((age IS NULL))" || len(ps) != 0 {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		if sqlStr, _ := Select(new(userEntity)).MatchFields("Unknown").ToSQL(nil); sqlStr != "" {
+			t.Fatal("test failed!")
+		}
+		if _, err := Select(new(userEntity)).MatchFields("Unknown").List(nil); err == nil || err.Error() != "anorm: unknown field [Unknown] of entity [*anorm.userEntity]" {
+			t.Fatalf("test failed! %v", err)
+		}
+		if _, err := SelectCount(new(userEntity)).MatchFields("Unknown").Count(nil); err == nil {
+			t.Fatal("test failed!")
+		}
+		if _, err := Delete(new(userEntity)).MatchFields("Unknown").Del(nil); err == nil {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestSelectMatchFields(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	op := Select(new(userEntity)).Columns("ID").MatchFields("Phone")
+	if sqlStr, ps := op.ToSQL(&userEntity{Name: testName}); sqlStr != "SELECT t.id AS ID FROM user_entity AS t WHERE ((name = ?) AND (phone = ?))" || !reflect.DeepEqual(ps, []any{testName, ""}) {
+		t.Fatalf("TestSelectMatchFields failed! %s %v", sqlStr, ps)
+	}
+	if es, err := op.List(&userEntity{Name: testName}); err != nil {
+		t.Fatalf("TestSelectMatchFields failed: %v\n", err)
+	} else if len(es) != 0 {
+		t.Fatal("TestSelectMatchFields failed!")
+	}
+	if es, err := Select(new(userEntity)).MatchFields("Phone").List(&userEntity{Name: testName, Phone: testPhone}); err != nil {
+		t.Fatalf("TestSelectMatchFields failed: %v\n", err)
+	} else if len(es) != 1 {
+		t.Fatal("TestSelectMatchFields failed!")
+	}
+}
+
+type _SelectiveJoin struct {
+	ID        int    `orm:"pk{T} c{id}"`
+	RelID     int    `orm:"c{rel_id}"`
+	OwnerID   int    `orm:"c{owner_id}"`
+	RelName   string `orm:"ig{T} ug{T} join{left,rel_id,join_rel,id,name}"`
+	OwnerName string `orm:"ig{T} ug{T} join{left,owner_id,join_owner,id,name}"`
+}
+
+func (_ *_SelectiveJoin) Configure(c *EC) {
+	c.Table = "selective_join"
+	c.JoinNullFields = map[string]*NullField{"RelName": {"IFNULL", "", true}}
+}
+
+func TestSelectSelectiveJoinToSQL(t *testing.T) {
+	if _, have := entityTableMap[getEntityPkgName(new(_SelectiveJoin))]; !have {
+		Register(new(_SelectiveJoin))
+	}
+	{
+		sqlStr, ps := Select(new(_SelectiveJoin)).Columns("ID", "RelName", "OwnerName").Join("RelName").OrderByField("RelName").ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID, IFNULL(rel2.name, ?) AS RelName FROM selective_join AS t LEFT JOIN join_rel AS rel2 ON rel2.id = t.rel_id ORDER BY rel2.name ASC" ||
+			!reflect.DeepEqual(ps, []any{""}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+	{
+		for _, op := range []SelectOperation[*_SelectiveJoin]{
+			Select(new(_SelectiveJoin)).Join("Typo"),
+			Select(new(_SelectiveJoin)).Join("RelName", "RelID"),
+			Select(new(_SelectiveJoin)).Join().CountJoin("rel_id"),
+		} {
+			if sqlStr, _ := op.ToSQL(nil); sqlStr != "" {
+				t.Fatal("test failed!")
+			}
+			if _, _, err := op.Page(nil, pagination.MySql, 0, 10); err == nil || !strings.HasPrefix(err.Error(), "anorm: unknown join field") {
+				t.Fatalf("test failed! %v", err)
+			}
+		}
+	}
+	{
+		op := Select(new(_SelectiveJoin)).Join("RelName").OrderByField("-OwnerName")
+		if sqlStr, _ := op.ToSQL(nil); sqlStr != "" {
+			t.Fatal("test failed!")
+		}
+		if _, err := op.List(nil); err == nil {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, _ := Select(new(_SelectiveJoin)).Columns("ID").Join().ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM selective_join AS t LEFT JOIN join_owner AS rel1 ON rel1.id = t.owner_id LEFT JOIN join_rel AS rel2 ON rel2.id = t.rel_id" {
+			t.Fatalf("test failed! %s", sqlStr)
+		}
+	}
+	{
+		countSQL, _, sqlStr, _ := Select(new(_SelectiveJoin)).Columns("ID", "OwnerName").Join().CountJoin("OwnerName").Where(sg.Eq("rel1.name", testName)).PageToSQL(nil, pagination.MySql, 0, 10)
+		if countSQL != "SELECT count(0) AS c FROM selective_join AS t LEFT JOIN join_owner AS rel1 ON rel1.id = t.owner_id WHERE ((rel1.name = ?))" ||
+			!strings.Contains(sqlStr, "LEFT JOIN join_rel AS rel2") {
+			t.Fatalf("test failed! %s %s", countSQL, sqlStr)
+		}
+	}
+}
+
+func TestSelectLimitToSQL(t *testing.T) {
+	{
+		sqlStr, ps := Select(new(userEntity)).Columns("ID").Distinct().Limit(10).ToSQL(nil)
+		if sqlStr != "SELECT DISTINCT t.id AS ID FROM user_entity AS t LIMIT ?" || !reflect.DeepEqual(ps, []any{10}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, ps := Select(new(userEntity)).Columns("ID").Where(sg.Eq("age", 9)).Limit(10).Offset(20).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t WHERE ((age = ?)) LIMIT ?, ?" || !reflect.DeepEqual(ps, []any{9, 20, 10}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		countSQL, countPs, _, _ := Select(new(userEntity)).Columns("Address").Distinct().Where(sg.Eq("age", 9)).OrderBy(sg.Asc(sg.C("address"))).PageToSQL(nil, pagination.MySql, 0, 10)
+		if countSQL != "SELECT count(0) AS c FROM (SELECT DISTINCT t.address AS Address FROM user_entity AS t WHERE ((age = ?))) AS _t" || !reflect.DeepEqual(countPs, []any{9}) {
+			t.Fatalf("test failed! %s", countSQL)
+		}
+	}
+	{
+		DataSourcePool.PushDB("TestSqlServerLimit", testDB)
+		DataSourcePool.SetDialect("TestSqlServerLimit", pagination.SqlServer("id asc"))
+		sqlStr, ps := SelectWithDs(new(userEntity), "TestSqlServerLimit").Columns("ID").OrderBy(sg.Desc(sg.C("t.id"))).Limit(10).Offset(20).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t ORDER BY t.id DESC OFFSET ? ROWS FETCH NEXT ? ROWS ONLY" || !reflect.DeepEqual(ps, []any{20, 10}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+		sqlStr, _ = SelectWithDs(new(userEntity), "TestSqlServerLimit").Columns("ID").Offset(20).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t ORDER BY id asc OFFSET ? ROWS" {
+			t.Fatalf("test failed! %s", sqlStr)
+		}
+	}
+	{
+		// the nil pager uses the datasource dialect
+		_, _, sqlStr, ps := Select(new(userEntity)).Columns("ID").PageToSQL(nil, nil, 20, 10)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t LIMIT ?, ?" || !reflect.DeepEqual(ps, []any{20, 10}) {
+			t.Fatalf("test failed! %s %v", sqlStr, ps)
+		}
+	}
+}
+
+func TestWheres(t *testing.T) {
+	if wheres, err := Wheres(Select(new(userEntity)).Where(sg.Eq("age", 9))); err != nil || len(wheres) != 1 {
+		t.Fatal("test failed!")
+	}
+	if _, err := Wheres(Select(new(userEntity)).Filter(1)); err == nil {
+		t.Fatal("test failed!")
+	}
+}
+
+func TestSelectFirstLast(t *testing.T) {
+	truncateTestTable()
+	_ = insertUserEntity("coco", 10, "wuhan", "130")
+	_ = insertUserEntity("hugo", 20, "wuhan", "131")
+	if e, err := Select(new(userEntity)).First(nil); err != nil {
+		t.Fatalf("TestSelectFirstLast failed: %v\n", err)
+	} else if e == nil || e.Name != "coco" {
+		t.Fatal("TestSelectFirstLast failed!")
+	}
+	if e, err := Select(new(userEntity)).OrderBy(sg.C("name")).Last(nil); err != nil {
+		t.Fatalf("TestSelectFirstLast failed: %v\n", err)
+	} else if e == nil || e.Name != "hugo" {
+		t.Fatal("TestSelectFirstLast failed!")
+	}
+	if e, err := Select(new(userEntity)).Where(sg.Eq("name", "none")).First(nil); err != nil || e != nil {
+		t.Fatal("TestSelectFirstLast failed!")
+	}
+	if es, err := Select(new(userEntity)).OrderBy(sg.C("id")).Limit(1).Offset(1).List(nil); err != nil {
+		t.Fatalf("TestSelectFirstLast failed: %v\n", err)
+	} else if len(es) != 1 || es[0].Name != "hugo" {
+		t.Fatal("TestSelectFirstLast failed!")
+	}
+}
+
+func TestSelectExists(t *testing.T) {
+	truncateTestTable()
+	_ = insertTest()
+	if exists, err := Select(new(userEntity)).Exists(&userEntity{Name: testName}); err != nil || !exists {
+		t.Fatal("TestSelectExists failed!")
+	}
+	if exists, err := Select(new(userEntity)).Where(sg.Eq("age", testAge+1)).Exists(&userEntity{Name: testName}); err != nil || exists {
+		t.Fatal("TestSelectExists failed!")
+	}
+	if exists, err := Select(new(userEntity)).Where(sg.Eq("age", testAge+1)).ExistsByPK(&userEntity{ID: 1}); err != nil || !exists {
+		t.Fatal("TestSelectExists failed!")
+	}
+	if exists, err := Select(new(userEntity)).ExistsByPK(&userEntity{ID: 2}); err != nil || exists {
+		t.Fatal("TestSelectExists failed!")
+	}
+	if _, err := Select(new(userEntity)).ExistsByPK(nil); err == nil {
+		t.Fatal("TestSelectExists failed!")
+	}
+}
+
+func TestSelectCursorPage(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 5; i++ {
+		_ = insertUserEntity(fmt.Sprintf("coco%d", i), i, "wuhan", "130")
+	}
+	ids := func(es []*userEntity) []int {
+		ids := make([]int, 0)
+		for _, e := range es {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+	es, next, prev, err := Select(new(userEntity)).CursorPage(nil, "", 2)
+	if err != nil || !reflect.DeepEqual(ids(es), []int{1, 2}) || next == "" || prev != "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, next, prev, err = Select(new(userEntity)).CursorPage(nil, next, 2)
+	if err != nil || !reflect.DeepEqual(ids(es), []int{3, 4}) || next == "" || prev == "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, next, prev, err = Select(new(userEntity)).CursorPage(nil, next, 2)
+	if err != nil || !reflect.DeepEqual(ids(es), []int{5}) || next != "" || prev == "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, next, prev, err = Select(new(userEntity)).CursorPage(nil, prev, 2)
+	if err != nil || !reflect.DeepEqual(ids(es), []int{3, 4}) || next == "" || prev == "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, next, prev, err = Select(new(userEntity)).Where(sg.Gt("age", 1)).CursorPage(nil, "", 3, "-Age", "-ID")
+	if err != nil || !reflect.DeepEqual(ids(es), []int{5, 4, 3}) || next == "" || prev != "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, _, prev, err = Select(new(userEntity)).Where(sg.Gt("age", 1)).CursorPage(nil, next, 3, "-Age", "-ID")
+	if err != nil || !reflect.DeepEqual(ids(es), []int{2}) || prev == "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	if _, _, _, err = Select(new(userEntity)).CursorPage(nil, "", 2, "Age", "-ID"); err == nil {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	if _, _, _, err = Select(new(userEntity)).CursorPage(nil, "haha", 2); err == nil {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, next, _, err = Select(new(userEntity)).CursorPage(nil, "", 3, "CreateTime", "ID")
+	if err != nil || !reflect.DeepEqual(ids(es), []int{1, 2, 3}) || next == "" {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+	es, _, _, err = Select(new(userEntity)).CursorPage(nil, next, 3, "CreateTime", "ID")
+	if err != nil || !reflect.DeepEqual(ids(es), []int{4, 5}) {
+		t.Fatal("TestSelectCursorPage failed!")
+	}
+}
+
+func TestSelectCursorPageSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		if _, _, _, err := Select(new(userEntity)).CursorPage(nil, "", size); err != errCursorSize {
+			t.Fatalf("TestSelectCursorPageSize failed: %v\n", err)
+		}
+	}
+}
+
+func TestSelectCursorValues(t *testing.T) {
+	now := time.Now()
+	{
+		o := Select(new(userEntity)).(*selectOperation[*userEntity])
+		c, err := pagination.DecodeCursor(o.getCursor(&userEntity{ID: 1, CreateTime: now}, []string{"CreateTime", "ID"}, false))
+		if err != nil {
+			t.Fatal("test failed!")
+		}
+		values, err := o.getCursorValues(c, []string{"CreateTime", "ID"})
+		if err != nil || len(values) != 2 || !values[0].(time.Time).Equal(now) || values[1] != 1 {
+			t.Fatalf("test failed! %v %v", values, err)
+		}
+	}
+	{
+		o := Select(new(userEntityNull)).(*selectOperation[*userEntityNull])
+		c, err := pagination.DecodeCursor(o.getCursor(&userEntityNull{ID: 1, CreateTime: sql.NullTime{Time: now, Valid: true}}, []string{"CreateTime", "ID"}, false))
+		if err != nil {
+			t.Fatal("test failed!")
+		}
+		values, err := o.getCursorValues(c, []string{"CreateTime", "ID"})
+		if err != nil || len(values) != 2 || !values[0].(sql.NullTime).Valid || !values[0].(sql.NullTime).Time.Equal(now) || values[1] != 1 {
+			t.Fatalf("test failed! %v %v", values, err)
+		}
+		if _, err = o.getCursorValues(c, []string{"CreateTime"}); err != pagination.ErrInvalidCursor {
+			t.Fatal("test failed!")
+		}
+	}
+}
+
+func TestSelectPageWithoutCount(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 3; i++ {
+		_ = insertTest()
+	}
+	if es, hasNext, err := Select(new(userEntity)).PageWithoutCount(nil, pagination.MySql, 0, 2); err != nil {
+		t.Fatalf("TestSelectPageWithoutCount failed: %v\n", err)
+	} else if len(es) != 2 || !hasNext {
+		t.Fatal("TestSelectPageWithoutCount failed!")
+	}
+	if es, hasNext, err := Select(new(userEntity)).PageWithoutCount(nil, pagination.MySql, 2, 2); err != nil {
+		t.Fatalf("TestSelectPageWithoutCount failed: %v\n", err)
+	} else if len(es) != 1 || hasNext {
+		t.Fatal("TestSelectPageWithoutCount failed!")
+	}
+}
+
+func TestSelectPageWithoutCountSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		if _, _, err := Select(new(userEntity)).PageWithoutCount(nil, pagination.MySql, 0, size); err != errPageSize {
+			t.Fatalf("TestSelectPageWithoutCountSize failed: %v\n", err)
+		}
+	}
+}
+
+func TestSelectPageEstimated(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 3; i++ {
+		_ = insertTest()
+	}
+	if es, estimated, err := Select(new(userEntity)).PageEstimated(nil, pagination.MySql, 0, 2); err != nil {
+		t.Fatalf("TestSelectPageEstimated failed: %v\n", err)
+	} else if len(es) != 2 || estimated < 2 {
+		t.Fatal("TestSelectPageEstimated failed!")
+	}
+}
+
+func TestSelectEach(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 3; i++ {
+		_ = insertTest()
+	}
+	count := 0
+	if err := Select(new(userEntity)).Each(nil, func(e *userEntity) error {
+		count++
+		return nil
+	}); err != nil || count != 3 {
+		t.Fatal("TestSelectEach failed!")
+	}
+	errStop := errors.New("anorm: test stop")
+	count = 0
+	if err := Select(new(userEntity)).Each(nil, func(e *userEntity) error {
+		count++
+		return errStop
+	}); !errors.Is(err, errStop) || count != 1 {
+		t.Fatal("TestSelectEach failed!")
+	}
+}
+
+func TestSelectChunk(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 5; i++ {
+		_ = insertTest()
+	}
+	sizes := make([]int, 0)
+	if err := Select(new(userEntity)).Chunk(nil, 2, func(es []*userEntity) error {
+		sizes = append(sizes, len(es))
+		return nil
+	}); err != nil || !reflect.DeepEqual(sizes, []int{2, 2, 1}) {
+		t.Fatal("TestSelectChunk failed!")
+	}
+	ids := make([]int, 0)
+	if err := Select(new(userEntity)).ChunkAfter(nil, 2, []any{3}, func(es []*userEntity) error {
+		for _, e := range es {
+			ids = append(ids, e.ID)
+		}
+		return nil
+	}); err != nil || !reflect.DeepEqual(ids, []int{4, 5}) {
+		t.Fatal("TestSelectChunk failed!")
+	}
+	if err := Select(new(userEntity)).ChunkAfter(nil, 2, []any{1, 2}, func(es []*userEntity) error { return nil }); err == nil {
+		t.Fatal("TestSelectChunk failed!")
+	}
+}
+
+func TestSelectChunkTx(t *testing.T) {
+	truncateTestTable()
+	for i := 1; i <= 3; i++ {
+		_ = insertTest()
+	}
+	errAbort := errors.New("anorm: test abort")
+	if err := Select(new(userEntity)).ChunkTx(nil, 2, nil, func(txm *TxManager, es []*userEntity) error {
+		o := Update(new(userEntity))
+		if err := o.BeginTx(txm); err != nil {
+			return err
+		}
+		for _, e := range es {
+			e.Name = "chunk"
+			if _, err := o.UpByPK(e); err != nil {
+				return err
+			}
+		}
+		if es[0].ID == 3 {
+			return errAbort
+		}
+		return nil
+	}); !errors.Is(err, errAbort) {
+		t.Fatal("TestSelectChunkTx failed!")
+	}
+	if c, _ := SelectCount(new(userEntity)).Count(&userEntity{Name: "chunk"}); c != 2 {
+		t.Fatal("TestSelectChunkTx failed!")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var errSortNotAllowed = func(field string) error {
+	return errors.New(fmt.Sprintf("anorm: sort field [%s] not allowed", field))
+}
+
+// ParseSort parse the user-supplied sort into the fields for OrderByField, e.g. "-createdAt,name" => [-CreatedAt Name]
+//
+// Each part is a registered field or column name of the entity, join fields included, matched case-insensitively,
+// prefix - for DESC, + or none for ASC, the blank parts are skipped
+//
+// Params:
+//
+// - e: the orm wrapper entity
+//
+// - sort: the comma separated sort fields
+//
+// - allowed: the whitelist fields or columns, all registered fields allowed if empty
+//
+// Returns:
+//
+// - fields: the field names, prefix - for DESC
+//
+// - err: the unknown or not allowed field error
+func ParseSort[E Entity](e E, sort string, allowed ...string) (fields []string, err error) {
+	allowedMap := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		fieldName, have := getSortField(e, a)
+		if !have {
+			return nil, errUnknownField(e, a)
+		}
+		allowedMap[fieldName] = struct{}{}
+	}
+	fields = make([]string, 0)
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		prefix := ""
+		if strings.HasPrefix(part, "-") {
+			prefix, part = "-", strings.TrimSpace(part[1:])
+		} else {
+			part = strings.TrimSpace(strings.TrimPrefix(part, "+"))
+		}
+		if part == "" {
+			continue
+		}
+		fieldName, have := getSortField(e, part)
+		if !have {
+			return nil, errUnknownField(e, part)
+		}
+		if _, have = allowedMap[fieldName]; len(allowedMap) > 0 && !have {
+			return nil, errSortNotAllowed(part)
+		}
+		fields = append(fields, prefix+fieldName)
+	}
+	return fields, nil
+}
+
+// getSortField return the registered field name of the field or column name, the exact names win
+//
+// The case-insensitive matches are tried in the order of the field names, the columns, then the join fields
+func getSortField[E Entity](e E, name string) (string, bool) {
+	entityPkgName := getEntityPkgName(e)
+	fieldColumnMap, joinRefMap := entityFieldColumnMap[entityPkgName], entityJoinRefMap[entityPkgName]
+	if _, have := fieldColumnMap[name]; have {
+		return name, true
+	}
+	if _, have := joinRefMap[name]; have {
+		return name, true
+	}
+	if fieldName, have := entityColumnFieldMap[entityPkgName][name]; have {
+		return fieldName, true
+	}
+	fieldNames := make([]string, 0, len(fieldColumnMap))
+	for fieldName := range fieldColumnMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		if strings.EqualFold(fieldName, name) {
+			return fieldName, true
+		}
+	}
+	for _, fieldName := range fieldNames {
+		if strings.EqualFold(fieldColumnMap[fieldName], name) {
+			return fieldName, true
+		}
+	}
+	joinFieldNames := make([]string, 0, len(joinRefMap))
+	for fieldName := range joinRefMap {
+		joinFieldNames = append(joinFieldNames, fieldName)
+	}
+	sort.Strings(joinFieldNames)
+	for _, fieldName := range joinFieldNames {
+		if strings.EqualFold(fieldName, name) {
+			return fieldName, true
+		}
+	}
+	return "", false
+}
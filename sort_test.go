@@ -0,0 +1,96 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+type _SortMaster struct {
+	ID      int    `orm:"pk{T} c{id}"`
+	RelID   int    `orm:"c{rel_id}"`
+	RelName string `orm:"ig{T} ug{T} join{left,rel_id,sort_rel,id,name}"`
+}
+
+func (_ *_SortMaster) Configure(c *EC) {
+	c.Table = "sort_master"
+}
+
+type _SortFold struct {
+	ID    int    `orm:"pk{T} c{id}"`
+	Name  string `orm:"c{title}"`
+	Title string `orm:"c{name}"`
+}
+
+func (_ *_SortFold) Configure(c *EC) {
+	c.Table = "sort_fold"
+}
+
+func TestParseSort(t *testing.T) {
+	{
+		fields, err := ParseSort(new(userEntity), " -createTime, +name,,age ")
+		if err != nil || !reflect.DeepEqual(fields, []string{"-CreateTime", "Name", "Age"}) {
+			t.Fatalf("test failed! %v %v", fields, err)
+		}
+	}
+	{
+		if _, err := ParseSort(new(userEntity), "-password"); err == nil {
+			t.Fatal("test failed!")
+		}
+		if _, err := ParseSort(new(userEntity), "phone", "Name", "create_time"); err == nil {
+			t.Fatal("test failed!")
+		}
+		if _, err := ParseSort(new(userEntity), "name", "unknown"); err == nil {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		fields, _ := ParseSort(new(userEntity), "-create_time,Name", "Name", "create_time")
+		sqlStr, _ := Select(new(userEntity)).Columns("ID").OrderByField(fields...).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM user_entity AS t ORDER BY t.create_time DESC, t.name ASC" {
+			t.Fatalf("test failed! %s", sqlStr)
+		}
+	}
+	{
+		if _, have := entityTableMap[getEntityPkgName(new(_SortMaster))]; !have {
+			Register(new(_SortMaster))
+		}
+		fields, err := ParseSort(new(_SortMaster), "-relName")
+		if err != nil || !reflect.DeepEqual(fields, []string{"-RelName"}) {
+			t.Fatalf("test failed! %v %v", fields, err)
+		}
+		op := Select(new(_SortMaster)).Columns("ID").OrderByField(fields...)
+		if _, err = op.List(nil); err == nil || err.Error() != "anorm: order by join field [RelName] requires Join called before" {
+			t.Fatalf("test failed! %v", err)
+		}
+		sqlStr, _ := Select(new(_SortMaster)).Columns("ID").Join().OrderByField(fields...).ToSQL(nil)
+		if sqlStr != "SELECT t.id AS ID FROM sort_master AS t LEFT JOIN sort_rel AS rel1 ON rel1.id = t.rel_id ORDER BY rel1.name DESC" {
+			t.Fatalf("test failed! %s", sqlStr)
+		}
+		if _, err = Select(new(_SortMaster)).OrderByField("-Unknown").List(nil); err == nil || err.Error() != "anorm: unknown field [Unknown] of entity [*anorm._SortMaster]" {
+			t.Fatalf("test failed! %v", err)
+		}
+	}
+	{
+		if _, have := entityTableMap[getEntityPkgName(new(_SortFold))]; !have {
+			Register(new(_SortFold))
+		}
+		// the field names win the columns case-insensitively
+		for i := 0; i < 10; i++ {
+			fields, err := ParseSort(new(_SortFold), "NAME,-TITLE")
+			if err != nil || !reflect.DeepEqual(fields, []string{"Name", "-Title"}) {
+				t.Fatalf("test failed! %v %v", fields, err)
+			}
+		}
+	}
+}
@@ -12,6 +12,7 @@
 package anorm
 
 import (
+	"context"
 	"database/sql"
 	"github.com/go-the-way/sg"
 	"reflect"
@@ -20,6 +21,7 @@ import (
 type (
 	UpdateOperation[E Entity] interface {
 		BeginTx(txm *TxManager, options ...*sql.TxOptions) error
+		WithContext(ctx context.Context) UpdateOperation[E]
 		Ignore(columns ...sg.C) UpdateOperation[E]
 		Set(columns ...sg.C) UpdateOperation[E]
 		IfWhere(cond bool, wheres ...sg.Ge) UpdateOperation[E]
@@ -27,6 +29,7 @@ type (
 		Where(wheres ...sg.Ge) UpdateOperation[E]
 		OnlyWhere(wheres ...sg.Ge) UpdateOperation[E]
 		UpByPK(e E) (c int64, err error)
+		ToSQL(e E) (sqlStr string, ps []any)
 	}
 	updateOperation[E Entity] struct {
 		orm                       *Orm[E]
@@ -51,6 +54,12 @@ func (o *updateOperation[E]) BeginTx(txm *TxManager, options ...*sql.TxOptions)
 	return o.orm.BeginTx(txm, options...)
 }
 
+// WithContext set the context passed to the entity hooks and the interceptors
+func (o *updateOperation[E]) WithContext(ctx context.Context) UpdateOperation[E] {
+	o.orm.WithContext(ctx)
+	return o
+}
+
 // Ignore ignore columns for updates
 func (o *updateOperation[E]) Ignore(columns ...sg.C) UpdateOperation[E] {
 	o.ignoreColumns = append(o.ignoreColumns, columns...)
@@ -64,7 +73,10 @@ func (o *updateOperation[E]) Set(columns ...sg.C) UpdateOperation[E] {
 }
 
 func (o *updateOperation[E]) getIgnoreMap() map[string]struct{} {
-	ignoreMap := entityUpdateIgnoreMap[getEntityPkgName(o.orm.entity)]
+	ignoreMap := make(map[string]struct{}, 0)
+	for c := range entityUpdateIgnoreMap[getEntityPkgName(o.orm.entity)] {
+		ignoreMap[c] = struct{}{}
+	}
 	for _, c := range o.ignoreColumns {
 		ignoreMap[string(c)] = struct{}{}
 	}
@@ -129,7 +141,7 @@ func (o *updateOperation[E]) getUpdateBuilder(entity E) (string, []any) {
 	}
 	for _, f := range fields {
 		column := fieldColumnMap[f]
-		val := rt.FieldByName(f).Interface()
+		val := sensitiveValue(getEntityPkgName(o.orm.entity), column, rt.FieldByName(f).Interface())
 		if appendEntityWhere {
 			if _, have := setMap[column]; !have {
 				if _, have = pkMap[column]; have {
@@ -151,6 +163,12 @@ func (o *updateOperation[E]) getUpdateBuilder(entity E) (string, []any) {
 	return builder.Set(setGes...).Where(sg.AndGroup(whereGes...)).Update(o.orm.table()).Build()
 }
 
+// ToSQL return the SQL and arguments UpByPK would execute, without touching the datasource
+func (o *updateOperation[E]) ToSQL(e E) (sqlStr string, ps []any) {
+	sqlStr, ps = o.getUpdateBuilder(e)
+	return sqlStr, unmaskArgs(ps)
+}
+
 // UpByPK select for page
 //
 // Params:
@@ -165,16 +183,17 @@ func (o *updateOperation[E]) getUpdateBuilder(entity E) (string, []any) {
 //
 func (o *updateOperation[E]) UpByPK(e E) (c int64, err error) {
 	var result sql.Result
+	if err = o.orm.beforeUpdate(e); err != nil {
+		return
+	}
 	sqlStr, ps := o.getUpdateBuilder(e)
-	queryLog("OpsForUpdate.UpByPK", sqlStr, ps)
-	if o.orm.openTx {
-		result, err = o.orm.tx.Exec(sqlStr, ps...)
-	} else {
-		result, err = o.orm.db.Exec(sqlStr, ps...)
+	result, err = o.orm.exec("OpsForUpdate.UpByPK", sqlStr, ps)
+	if err != nil {
+		return
 	}
-	queryErrorLog(err, "OpsForUpdate.UpByPK", sqlStr, ps)
 	if result != nil {
 		c, _ = result.RowsAffected()
 	}
+	err = o.orm.afterUpdate(e)
 	return
 }
@@ -13,6 +13,7 @@ package anorm
 
 import (
 	"github.com/go-the-way/sg"
+	"reflect"
 	"testing"
 )
 
@@ -182,3 +183,29 @@ func TestUpdateBeginTx(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestUpdateToSQL(t *testing.T) {
+	e := &userEntity{ID: 1, Name: "coco", Age: 9, Address: "wuhan", Phone: "130"}
+	{
+		sqlStr, ps := Update(new(userEntity)).ToSQL(e)
+		if sqlStr != "UPDATE user_entity SET name = ?, age = ?, address = ?, phone = ? WHERE ((id = ?))" || !reflect.DeepEqual(ps, []any{"coco", 9, "wuhan", "130", 1}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		sqlStr, ps := Update(new(userEntity)).Set("name").OnlyWhere(sg.Eq("age", 9)).ToSQL(e)
+		if sqlStr != "UPDATE user_entity SET name = ? WHERE ((age = ?))" || !reflect.DeepEqual(ps, []any{"coco", 9}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		// Ignore does not leak into the registered update ignores
+		sqlStr, _ := Update(new(userEntity)).Ignore("phone").ToSQL(e)
+		if sqlStr != "UPDATE user_entity SET name = ?, age = ?, address = ? WHERE ((id = ?))" {
+			t.Fatal("test failed!")
+		}
+		if sqlStr, _ = Update(new(userEntity)).ToSQL(e); sqlStr != "UPDATE user_entity SET name = ?, age = ?, address = ?, phone = ? WHERE ((id = ?))" {
+			t.Fatal("test failed!")
+		}
+	}
+}
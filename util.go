@@ -13,6 +13,7 @@ package anorm
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -55,35 +56,118 @@ func getStrategyName(str string, sty Strategy) string {
 }
 
 // ScanStruct scan rows return []E
-func ScanStruct[E Entity](rows *sql.Rows, entity E, complete func(entity EntityConfigurator)) ([]E, error) {
-	defer func() { _ = rows.Close() }()
-	ptr := reflect.TypeOf(entity)
+func ScanStruct[E Entity](rows *sql.Rows, entity E, complete func(entity EntityConfigurator), mode ...ColumnMode) ([]E, error) {
 	result := make([]E, 0)
-	if columns, err := rows.Columns(); err != nil {
+	if err := EachStruct(rows, entity, complete, func(e E) error {
+		result = append(result, e)
+		return nil
+	}, mode...); err != nil {
 		return nil, err
-	} else {
-		for rows.Next() {
-			structVal := reflect.New(ptr.Elem())
-			if err2 := rows.Scan(NewColumnPtr(structVal, columns)...); err2 != nil {
-				return nil, err2
-			}
-			e := structVal.Interface().(E)
-			if complete != nil {
-				complete(e)
+	}
+	return result, nil
+}
+
+// EachStruct scan rows one by one, call fn with each E
+//
+// The columns are mapped like Scan, the columns without field are scanned by the mode, default ColumnStrict.
+// The rows are closed when returned, a non-nil error returned by fn stops the scan and is returned
+func EachStruct[E Entity](rows *sql.Rows, entity E, complete func(entity EntityConfigurator), fn func(e E) error, mode ...ColumnMode) error {
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	s, err := newRowScanner(reflect.TypeOf(entity), columns, getColumnMode(mode))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		v, err2 := s.scan(rows)
+		if err2 != nil {
+			return err2
+		}
+		e := v.Interface().(E)
+		if complete != nil {
+			complete(e)
+		}
+		if err2 = fn(e); err2 != nil {
+			return err2
+		}
+	}
+	return rows.Err()
+}
+
+// scanMaps scan rows return the columns and rows keyed by the column, []byte values converted to string
+func scanMaps(rows *sql.Rows) ([]string, []map[string]any, error) {
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	ms := make([]map[string]any, 0)
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pts := make([]any, len(columns))
+		for i := range values {
+			pts[i] = &values[i]
+		}
+		if err = rows.Scan(pts...); err != nil {
+			return nil, nil, err
+		}
+		m := make(map[string]any, len(columns))
+		for i, c := range columns {
+			if bs, ok := values[i].([]byte); ok {
+				values[i] = string(bs)
 			}
-			result = append(result, e)
+			m[c] = values[i]
 		}
+		ms = append(ms, m)
 	}
-	return result, nil
+	return columns, ms, rows.Err()
 }
 
-// NewColumnPtr return column ptr array
-func NewColumnPtr(structVal reflect.Value, columns []string) []any {
-	pts := make([]any, len(columns))
-	for i, c := range columns {
-		pts[i] = structVal.Elem().FieldByName(c).Addr().Interface()
+// scanSlice scan rows into dest, dest is a pointer to []T or []map[string]any, see Scan
+func scanSlice(rows *sql.Rows, dest any) error {
+	sliceVal := reflect.ValueOf(dest)
+	if sliceVal.Kind() != reflect.Pointer || sliceVal.Elem().Kind() != reflect.Slice {
+		_ = rows.Close()
+		return errors.New(fmt.Sprintf("anorm: scan dest must be a pointer to slice, got %T", dest))
+	}
+	sliceVal = sliceVal.Elem()
+	elemType := sliceVal.Type().Elem()
+	if elemType == reflect.TypeOf(map[string]any{}) {
+		_, ms, err := scanMaps(rows)
+		if err != nil {
+			return err
+		}
+		for _, m := range ms {
+			sliceVal.Set(reflect.Append(sliceVal, reflect.ValueOf(m)))
+		}
+		return nil
 	}
-	return pts
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	s, err := newRowScanner(elemType, columns, ColumnStrict)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		v, err2 := s.scan(rows)
+		if err2 != nil {
+			return err2
+		}
+		sliceVal.Set(reflect.Append(sliceVal, v))
+	}
+	return rows.Err()
+}
+
+// NewColumnPtr return column ptr array, the columns are mapped like Scan, the columns without field are discarded
+func NewColumnPtr(structVal reflect.Value, columns []string) []any {
+	paths, _ := getColumnPaths(structVal.Type().Elem(), columns, ColumnLenient)
+	return newPathPtrs(structVal.Elem(), paths)
 }
 
 func entityNotNil(entity Entity) bool {
@@ -71,3 +71,20 @@ func TestScanStruct(t *testing.T) {
 		}
 	}
 }
+
+func TestScanStructColumnMode(t *testing.T) {
+	{
+		if rows, err := testDB.Query("select 'coco' as NAME, 'hugo' as name2, 1 as Extra"); err != nil {
+			t.Error("TestScanStructColumnMode failed")
+		} else if _, err2 := ScanStruct(rows, new(_scanStruct), nil); err2 == nil || err2.Error() != "anorm: column[Extra] has no field in anorm._scanStruct" {
+			t.Error("TestScanStructColumnMode failed")
+		}
+	}
+	{
+		if rows, err := testDB.Query("select 'coco' as NAME, 'hugo' as name2, 1 as Extra"); err != nil {
+			t.Error("TestScanStructColumnMode failed")
+		} else if es, err2 := ScanStruct(rows, new(_scanStruct), nil, ColumnLenient); err2 != nil || len(es) != 1 || es[0].Name != "coco" || es[0].Name2 != "hugo" {
+			t.Error("TestScanStructColumnMode failed")
+		}
+	}
+}
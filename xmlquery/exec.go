@@ -13,46 +13,45 @@ package xmlquery
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
-	"github.com/go-the-way/anorm"
 	"text/template"
 )
 
 type (
 	Executable interface {
+		WithContext(ctx context.Context) Executable
 		Exec(ps ...any) (int64, error)
 		ExecTemplate(data any) (int64, error)
 	}
 	executableImpl struct {
-		ds              string
-		db              *sql.DB
+		*nodeRunner
 		sqlStr, tSqlStr string
 	}
 )
 
 func executable(namespace, id string, nodeType nodeType) Executable {
-	rn, nd := getNode(namespace, id, nodeType)
-	datasource := getDS(rn, nd)
-	if datasource == "" {
-		datasource = "_"
-	}
-	return &executableImpl{datasource, anorm.DataSourcePool.Required(datasource), nd.GetInnerXml(), ""}
+	runner, sqlStr := getNodeParams(namespace, id, nodeType)
+	return &executableImpl{runner, sqlStr, ""}
 }
 
 func Insert(namespace, id string) Executable { return executable(namespace, id, insertType) } // Insert return Executable
 func Delete(namespace, id string) Executable { return executable(namespace, id, deleteType) } // Delete return Executable
 func Update(namespace, id string) Executable { return executable(namespace, id, updateType) } // Update return Executable
 
+// WithContext set the context passed to the interceptors
+func (e *executableImpl) WithContext(ctx context.Context) Executable {
+	e.ctx = ctx
+	return e
+}
+
 func (e *executableImpl) Exec(ps ...any) (c int64, err error) {
 	sqlStr := e.tSqlStr
 	if sqlStr == "" {
 		sqlStr = e.sqlStr
 	}
 	var result sql.Result
-	queryLog("Executable.Exec", sqlStr, ps...)
-	if result, err = e.db.Exec(sqlStr, ps...); err != nil {
-		queryErrorLog(err, "Executable.Exec", sqlStr, ps...)
-	} else if result != nil {
+	if result, err = e.exec("Executable.Exec", sqlStr, ps...); err == nil && result != nil {
 		c, err = result.RowsAffected()
 	}
 	return
@@ -12,6 +12,7 @@
 package xmlquery
 
 import (
+	"context"
 	"database/sql"
 	"github.com/go-the-way/anorm"
 )
@@ -82,14 +83,50 @@ func (n *selectNode) getDatasource() string { return n.Datasource }
 func (n *selectNode) GetID() string         { return n.ID }
 func (n *selectNode) GetInnerXml() string   { return n.InnerXml }
 
-func queryLog(name, sql string, ps ...any) {
-	anorm.Logger.Debug([]*anorm.LogF{anorm.LogField("Name", name), anorm.LogField("SQL", sql), anorm.LogField("Parameter", ps)}, "")
+// RedactParam defines return true if the parameter of the node is sensitive, it's masked in the logs
+//
+// The parameters marked by anorm.Sensitive are always masked
+var RedactParam = func(namespace, id string, index int, param any) bool { return false }
+
+// nodeRunner runs the statements of a node
+type nodeRunner struct {
+	namespace, id, ds string
+	db                *sql.DB
+	ctx               context.Context // the context passed to the interceptors, context.Background() if nil
+}
+
+func (r *nodeRunner) context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
 }
 
-func queryErrorLog(err error, name, sql string, ps ...any) {
-	if err != nil {
-		anorm.Logger.Error([]*anorm.LogF{anorm.LogField("Name", name), anorm.LogField("SQL", sql), anorm.LogField("Parameter", ps)}, "err: %v", err)
+func (r *nodeRunner) statement(name, sqlStr string, ps []any) *anorm.Statement {
+	args := make([]any, len(ps))
+	for i, p := range ps {
+		if RedactParam(r.namespace, r.id, i, p) {
+			p = anorm.Sensitive(p)
+		}
+		args[i] = p
 	}
+	return &anorm.Statement{Name: name, SQL: sqlStr, Args: args, DS: r.ds}
+}
+
+func (r *nodeRunner) exec(name, sqlStr string, ps ...any) (sql.Result, error) {
+	return anorm.ExecStatement(r.context(), r.db, r.statement(name, sqlStr, ps))
+}
+
+func (r *nodeRunner) query(name, sqlStr string, ps ...any) (*sql.Rows, error) {
+	return anorm.QueryStatement(r.context(), r.db, r.statement(name, sqlStr, ps))
+}
+
+func (r *nodeRunner) queryRow(name, sqlStr string, ps ...any) (*sql.Row, error) {
+	return anorm.QueryRowStatement(r.context(), r.db, r.statement(name, sqlStr, ps))
+}
+
+func (r *nodeRunner) explain(name, sqlStr string, ps ...any) (*anorm.Plan, error) {
+	return anorm.ExplainStatement(r.context(), r.db, r.statement(name, sqlStr, ps))
 }
 
 func getDS(rn *rootNode, nd node) string {
@@ -102,11 +139,11 @@ func getDS(rn *rootNode, nd node) string {
 	return ""
 }
 
-func getNodeParams(namespace, id string, nodeType nodeType) (string, *sql.DB, string) {
+func getNodeParams(namespace, id string, nodeType nodeType) (*nodeRunner, string) {
 	rn, nd := getNode(namespace, id, nodeType)
 	datasource := getDS(rn, nd)
 	if datasource == "" {
 		datasource = "_"
 	}
-	return datasource, anorm.DataSourcePool.Required(datasource), nd.GetInnerXml()
+	return &nodeRunner{namespace: namespace, id: id, ds: datasource, db: anorm.DataSourcePool.Required(datasource)}, nd.GetInnerXml()
 }
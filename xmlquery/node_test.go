@@ -11,7 +11,12 @@
 
 package xmlquery
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-the-way/anorm"
+)
 
 func TestGetDS(t *testing.T) {
 	if ds := getDS(&rootNode{Datasource: "haha"}, &insertNode{"", "haha2", ""}); ds != "haha2" {
@@ -21,3 +26,22 @@ func TestGetDS(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestRedactParam(t *testing.T) {
+	defer func(f func(namespace, id string, index int, param any) bool) { RedactParam = f }(RedactParam)
+	RedactParam = func(namespace, id string, index int, param any) bool {
+		return namespace == "user" && id == "login" && index == 1
+	}
+	{
+		stmt := (&nodeRunner{namespace: "user", id: "login"}).statement("Test", "SQL", []any{"coco", "123456"})
+		if !reflect.DeepEqual(stmt.Args, []any{"coco", anorm.Sensitive("123456")}) {
+			t.Fatal("test failed!")
+		}
+	}
+	{
+		stmt := (&nodeRunner{namespace: "user", id: "list"}).statement("Test", "SQL", []any{"coco", "123456"})
+		if !reflect.DeepEqual(stmt.Args, []any{"coco", "123456"}) {
+			t.Fatal("test failed!")
+		}
+	}
+}
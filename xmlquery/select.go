@@ -13,7 +13,7 @@ package xmlquery
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"errors"
 	"github.com/go-the-way/anorm"
 	"html/template"
@@ -25,21 +25,28 @@ var (
 
 type (
 	Selectable[E anorm.Entity] interface {
+		WithContext(ctx context.Context) Selectable[E]
 		List(entity E, ps ...any) ([]E, error)
 		One(entity E, ps ...any) (E, error)
 		ListTemplate(entity E, data any) ([]E, error)
 		OneTemplate(entity E, data any) (E, error)
+		Explain(ps ...any) (*anorm.Plan, error)
 	}
 	selectableImpl[E anorm.Entity] struct {
-		ds              string
-		db              *sql.DB
+		*nodeRunner
 		sqlStr, tSqlStr string
 	}
 )
 
 func Select[E anorm.Entity](namespace, id string) Selectable[E] {
-	datasource, db, sqlStr := getNodeParams(namespace, id, selectType)
-	return &selectableImpl[E]{datasource, db, sqlStr, ""}
+	runner, sqlStr := getNodeParams(namespace, id, selectType)
+	return &selectableImpl[E]{runner, sqlStr, ""}
+}
+
+// WithContext set the context passed to the interceptors
+func (q *selectableImpl[E]) WithContext(ctx context.Context) Selectable[E] {
+	q.ctx = ctx
+	return q
 }
 
 func (q *selectableImpl[E]) List(e E, ps ...any) ([]E, error) {
@@ -47,10 +54,8 @@ func (q *selectableImpl[E]) List(e E, ps ...any) ([]E, error) {
 	if sqlStr == "" {
 		sqlStr = q.sqlStr
 	}
-	queryLog("Selectable.List", sqlStr, ps...)
-	rows, err := q.db.Query(sqlStr, ps...)
+	rows, err := q.query("Selectable.List", sqlStr, ps...)
 	if err != nil {
-		queryErrorLog(err, "Selectable.List", sqlStr, ps...)
 		return nil, err
 	}
 	return anorm.ScanStruct(rows, e, nil)
@@ -91,3 +96,12 @@ func (q *selectableImpl[E]) OneTemplate(ie E, data any) (oe E, err error) {
 	}
 	return
 }
+
+// Explain run the datasource dialect's EXPLAIN on the statement List would execute
+func (q *selectableImpl[E]) Explain(ps ...any) (*anorm.Plan, error) {
+	sqlStr := q.tSqlStr
+	if sqlStr == "" {
+		sqlStr = q.sqlStr
+	}
+	return q.explain("Selectable.List", sqlStr, ps...)
+}
@@ -13,7 +13,7 @@ package xmlquery
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"fmt"
 	"github.com/go-the-way/anorm"
 	"github.com/go-the-way/anorm/pagination"
@@ -22,19 +22,26 @@ import (
 
 type (
 	PageSelectable[E anorm.Entity] interface {
+		WithContext(ctx context.Context) PageSelectable[E]
 		List(entity E, pager pagination.Pager, offset, size int, ps ...any) ([]E, int, error)
 		ListTemplate(entity E, pager pagination.Pager, offset, size int, data any) ([]E, int, error)
+		Explain(pager pagination.Pager, offset, size int, ps ...any) (*anorm.Plan, error)
 	}
 	pageSelectableImpl[E anorm.Entity] struct {
-		ds              string
-		db              *sql.DB
+		*nodeRunner
 		sqlStr, tSqlStr string
 	}
 )
 
 func PageSelect[E anorm.Entity](namespace, id string) PageSelectable[E] {
-	datasource, db, sqlStr := getNodeParams(namespace, id, selectType)
-	return &pageSelectableImpl[E]{datasource, db, sqlStr, ""}
+	runner, sqlStr := getNodeParams(namespace, id, selectType)
+	return &pageSelectableImpl[E]{runner, sqlStr, ""}
+}
+
+// WithContext set the context passed to the interceptors
+func (q *pageSelectableImpl[E]) WithContext(ctx context.Context) PageSelectable[E] {
+	q.ctx = ctx
+	return q
 }
 
 func (q *pageSelectableImpl[E]) List(entity E, pager pagination.Pager, offset, size int, ps ...any) ([]E, int, error) {
@@ -43,11 +50,12 @@ func (q *pageSelectableImpl[E]) List(entity E, pager pagination.Pager, offset, s
 		sqlStr = q.sqlStr
 	}
 	pageSqlStr := fmt.Sprintf("select count(0) from (%s) as _t", sqlStr)
-	queryLog("PageSelect.SelectCount", pageSqlStr, ps...)
 	c := 0
-	row := q.db.QueryRow(pageSqlStr)
-	if err := row.Scan(&c); err != nil {
-		queryErrorLog(err, "PageSelect.SelectCount", pageSqlStr, ps...)
+	row, err := q.queryRow("PageSelect.SelectCount", pageSqlStr, ps...)
+	if err != nil {
+		return nil, c, err
+	}
+	if err = row.Scan(&c); err != nil {
 		return nil, c, err
 	}
 	if c <= 0 {
@@ -57,10 +65,8 @@ func (q *pageSelectableImpl[E]) List(entity E, pager pagination.Pager, offset, s
 	newPs := make([]any, 0)
 	newPs = append(newPs, ps...)
 	newPs = append(newPs, ps2...)
-	rows, err := q.db.Query(nSqlStr, newPs...)
-	queryLog("PageSelect.List", nSqlStr, newPs...)
+	rows, err := q.query("PageSelect.List", nSqlStr, newPs...)
 	if err != nil {
-		queryErrorLog(err, "PageSelect.List", nSqlStr, newPs...)
 		return nil, 0, err
 	}
 	es, err := anorm.ScanStruct(rows, entity, nil)
@@ -79,3 +85,16 @@ func (q *pageSelectableImpl[E]) ListTemplate(entity E, pager pagination.Pager, o
 		return q.List(entity, pager, offset, size)
 	}
 }
+
+// Explain run the datasource dialect's EXPLAIN on the page statement List would execute
+func (q *pageSelectableImpl[E]) Explain(pager pagination.Pager, offset, size int, ps ...any) (*anorm.Plan, error) {
+	sqlStr := q.tSqlStr
+	if sqlStr == "" {
+		sqlStr = q.sqlStr
+	}
+	nSqlStr, ps2 := pager.Page(sqlStr, offset, size)
+	newPs := make([]any, 0)
+	newPs = append(newPs, ps...)
+	newPs = append(newPs, ps2...)
+	return q.explain("PageSelect.List", nSqlStr, newPs...)
+}
@@ -68,6 +68,31 @@ func TestPageSelectList(t *testing.T) {
 	}
 }
 
+type testPageSelectListParamsE struct{ T string }
+
+func (t *testPageSelectListParamsE) Configure(*anorm.EC) {}
+
+// the count query binds the same params as the page query
+func TestPageSelectListParams(t *testing.T) {
+	type eE = testPageSelectListParamsE
+	var XML = `
+ <?xml version="1.0" encoding="utf-8"?>
+ <xmlquery namespace="TestPageSelectListParams" datasource="">
+ 
+ 	<select id="selectPage">
+ 		select * from (select 'Haha' as T union all select '1') as _s where T = ?
+ 	</select>
+ 	
+ </xmlquery>
+ `
+	BindXml(XML)
+	if es, c, err := PageSelect[*eE]("TestPageSelectListParams", "selectPage").List(new(eE), pagination.MySql, 0, 10, "Haha"); err != nil {
+		t.Fatal("test failed!")
+	} else if len(es) != 1 || c != 1 {
+		t.Fatal("test failed!")
+	}
+}
+
 type testPageSelectListQueryCountErrE struct{ T string }
 
 func (t *testPageSelectListQueryCountErrE) Configure(*anorm.EC) {}
@@ -13,36 +13,52 @@ package xmlquery
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
+	"github.com/go-the-way/anorm"
 	"text/template"
 )
 
 type (
 	RowsSelectable interface {
+		WithContext(ctx context.Context) RowsSelectable
 		Row(ps ...any) *sql.Row
+		QueryRow(ps ...any) (*sql.Row, error)
 		Rows(ps ...any) (*sql.Rows, error)
 		RowTemplate(data any) (*sql.Row, error)
 		RowsTemplate(data any) (*sql.Rows, error)
+		Explain(ps ...any) (*anorm.Plan, error)
 	}
 	rowsSelectableImpl struct {
-		ds              string
-		db              *sql.DB
+		*nodeRunner
 		sqlStr, tSqlStr string
 	}
 )
 
 func RowsSelect(namespace, id string) RowsSelectable {
-	datasource, db, sqlStr := getNodeParams(namespace, id, selectType)
-	return &rowsSelectableImpl{datasource, db, sqlStr, ""}
+	runner, sqlStr := getNodeParams(namespace, id, selectType)
+	return &rowsSelectableImpl{runner, sqlStr, ""}
 }
 
+// WithContext set the context passed to the interceptors
+func (q *rowsSelectableImpl) WithContext(ctx context.Context) RowsSelectable {
+	q.ctx = ctx
+	return q
+}
+
+// Row query the row, nil if an interceptor returns an error, see QueryRow
 func (q *rowsSelectableImpl) Row(ps ...any) *sql.Row {
+	row, _ := q.QueryRow(ps...)
+	return row
+}
+
+// QueryRow query the row like Row, return the error returned by the interceptors
+func (q *rowsSelectableImpl) QueryRow(ps ...any) (*sql.Row, error) {
 	sqlStr := q.tSqlStr
 	if sqlStr == "" {
 		sqlStr = q.sqlStr
 	}
-	queryLog("RowsSelectable.Row", sqlStr, ps...)
-	return q.db.QueryRow(sqlStr, ps...)
+	return q.queryRow("RowsSelectable.Row", sqlStr, ps...)
 }
 
 func (q *rowsSelectableImpl) Rows(ps ...any) (*sql.Rows, error) {
@@ -50,8 +66,7 @@ func (q *rowsSelectableImpl) Rows(ps ...any) (*sql.Rows, error) {
 	if sqlStr == "" {
 		sqlStr = q.sqlStr
 	}
-	queryLog("RowsSelectable.Rows", sqlStr, ps...)
-	return q.db.Query(sqlStr, ps...)
+	return q.query("RowsSelectable.Rows", sqlStr, ps...)
 }
 
 func (q *rowsSelectableImpl) RowTemplate(data any) (*sql.Row, error) {
@@ -64,7 +79,7 @@ func (q *rowsSelectableImpl) RowTemplate(data any) (*sql.Row, error) {
 		} else {
 			q.tSqlStr = buf.String()
 		}
-		return q.Row(), nil
+		return q.QueryRow()
 	}
 }
 
@@ -81,3 +96,12 @@ func (q *rowsSelectableImpl) RowsTemplate(data any) (*sql.Rows, error) {
 		return q.Rows()
 	}
 }
+
+// Explain run the datasource dialect's EXPLAIN on the statement Rows would execute
+func (q *rowsSelectableImpl) Explain(ps ...any) (*anorm.Plan, error) {
+	sqlStr := q.tSqlStr
+	if sqlStr == "" {
+		sqlStr = q.sqlStr
+	}
+	return q.explain("RowsSelectable.Rows", sqlStr, ps...)
+}
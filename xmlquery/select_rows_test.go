@@ -12,7 +12,12 @@
 package xmlquery
 
 import (
+	"context"
+	"errors"
+	"github.com/go-the-way/anorm"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestRowsSelect(t *testing.T) {
@@ -49,6 +54,73 @@ func TestRowsSelectRow(t *testing.T) {
 	}
 }
 
+func TestRowsSelectRowInterceptorErr(t *testing.T) {
+	var XML = `
+ <?xml version="1.0" encoding="utf-8"?>
+ <xmlquery namespace="TestRowsSelectRowInterceptorErr" datasource="">
+ 
+ 	<select id="selectNow">
+ 		select 'Haha' as T /* TestRowsSelectRowInterceptorErr */
+ 	</select>
+ 	
+ </xmlquery>
+ `
+	BindXml(XML)
+	errDenied := errors.New("denied")
+	anorm.UseInterceptor(func(ctx context.Context, stmt *anorm.Statement, invoker anorm.Invoker) (any, time.Duration, error) {
+		if strings.Contains(stmt.SQL, "TestRowsSelectRowInterceptorErr") {
+			return nil, 0, errDenied
+		}
+		return invoker(ctx, stmt)
+	})
+	if r, err := RowsSelect("TestRowsSelectRowInterceptorErr", "selectNow").QueryRow(); !errors.Is(err, errDenied) || r != nil {
+		t.Fatal("test failed!")
+	}
+	if r := RowsSelect("TestRowsSelectRowInterceptorErr", "selectNow").Row(); r != nil {
+		t.Fatal("test failed!")
+	}
+}
+
+type testCtxKey struct{}
+
+func TestRowsSelectWithContext(t *testing.T) {
+	var XML = `
+ <?xml version="1.0" encoding="utf-8"?>
+ <xmlquery namespace="TestRowsSelectWithContext" datasource="">
+ 
+ 	<select id="selectNow">
+ 		select 'Haha' as T /* TestRowsSelectWithContext */
+ 	</select>
+ 	
+ 	<update id="updateNow">
+ 		update t set a = 1 /* TestRowsSelectWithContext */
+ 	</update>
+ 	
+ </xmlquery>
+ `
+	BindXml(XML)
+	errDenied := errors.New("denied")
+	anorm.UseInterceptor(func(ctx context.Context, stmt *anorm.Statement, invoker anorm.Invoker) (any, time.Duration, error) {
+		if strings.Contains(stmt.SQL, "TestRowsSelectWithContext") {
+			if v, _ := ctx.Value(testCtxKey{}).(string); v != "" {
+				return nil, 0, errors.New(v)
+			}
+			return nil, 0, errDenied
+		}
+		return invoker(ctx, stmt)
+	})
+	ctx := context.WithValue(context.Background(), testCtxKey{}, "traced")
+	if _, err := RowsSelect("TestRowsSelectWithContext", "selectNow").WithContext(ctx).QueryRow(); err == nil || err.Error() != "traced" {
+		t.Fatalf("test failed! %v", err)
+	}
+	if _, err := RowsSelect("TestRowsSelectWithContext", "selectNow").QueryRow(); !errors.Is(err, errDenied) {
+		t.Fatalf("test failed! %v", err)
+	}
+	if _, err := Update("TestRowsSelectWithContext", "updateNow").WithContext(ctx).Exec(); err == nil || err.Error() != "traced" {
+		t.Fatalf("test failed! %v", err)
+	}
+}
+
 func TestRowsSelectRows(t *testing.T) {
 	var XML = `
  <?xml version="1.0" encoding="utf-8"?>
@@ -0,0 +1,102 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xmlquery
+
+import (
+	"bytes"
+	"context"
+	"github.com/go-the-way/anorm"
+	"text/template"
+)
+
+type (
+	// ScanSelectable select into any T, the columns mapped like anorm.Scan
+	ScanSelectable[T any] interface {
+		WithContext(ctx context.Context) ScanSelectable[T]
+		List(ps ...any) ([]T, error)
+		One(ps ...any) (T, error)
+		ListTemplate(data any) ([]T, error)
+		OneTemplate(data any) (T, error)
+		Explain(ps ...any) (*anorm.Plan, error)
+	}
+	scanSelectableImpl[T any] struct {
+		*nodeRunner
+		sqlStr, tSqlStr string
+	}
+)
+
+func ScanSelect[T any](namespace, id string) ScanSelectable[T] {
+	runner, sqlStr := getNodeParams(namespace, id, selectType)
+	return &scanSelectableImpl[T]{runner, sqlStr, ""}
+}
+
+// WithContext set the context passed to the interceptors
+func (q *scanSelectableImpl[T]) WithContext(ctx context.Context) ScanSelectable[T] {
+	q.ctx = ctx
+	return q
+}
+
+func (q *scanSelectableImpl[T]) List(ps ...any) ([]T, error) {
+	sqlStr := q.tSqlStr
+	if sqlStr == "" {
+		sqlStr = q.sqlStr
+	}
+	rows, err := q.query("ScanSelectable.List", sqlStr, ps...)
+	if err != nil {
+		return nil, err
+	}
+	return anorm.Scan[T](rows)
+}
+
+func (q *scanSelectableImpl[T]) One(ps ...any) (t T, err error) {
+	if ts, err2 := q.List(ps...); err2 != nil {
+		err = err2
+	} else if len(ts) > 1 {
+		err = ErrSelectTooManyResult
+	} else if len(ts) == 1 {
+		t = ts[0]
+	}
+	return
+}
+
+func (q *scanSelectableImpl[T]) ListTemplate(data any) ([]T, error) {
+	if temp, err := template.New("QUERY").Parse(q.sqlStr); err != nil {
+		return nil, err
+	} else {
+		var buf = bytes.Buffer{}
+		if err := temp.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		q.tSqlStr = buf.String()
+		return q.List()
+	}
+}
+
+func (q *scanSelectableImpl[T]) OneTemplate(data any) (t T, err error) {
+	if ts, err2 := q.ListTemplate(data); err2 != nil {
+		err = err2
+	} else if len(ts) > 1 {
+		err = ErrSelectTooManyResult
+	} else if len(ts) == 1 {
+		t = ts[0]
+	}
+	return
+}
+
+// Explain run the datasource dialect's EXPLAIN on the statement List would execute
+func (q *scanSelectableImpl[T]) Explain(ps ...any) (*anorm.Plan, error) {
+	sqlStr := q.tSqlStr
+	if sqlStr == "" {
+		sqlStr = q.sqlStr
+	}
+	return q.explain("ScanSelectable.List", sqlStr, ps...)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 anorm Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xmlquery
+
+import (
+	"testing"
+)
+
+type testScanDTO struct {
+	UserName string `db:"user_name"`
+	Age      int
+}
+
+func TestScanSelect(t *testing.T) {
+	var XML = `
+ <?xml version="1.0" encoding="utf-8"?>
+ <xmlquery namespace="TestScanSelect" datasource="">
+ 
+ 	<select id="selectNow">
+ 		select 'Haha' as user_name, 1 as age union all select 'Hugo', 2
+ 	</select>
+ 	
+ 	<select id="selectName">
+ 		select 'Haha' as name
+ 	</select>
+ 	
+ </xmlquery>
+ `
+	BindXml(XML)
+	if ts, err := ScanSelect[testScanDTO]("TestScanSelect", "selectNow").List(); err != nil {
+		t.Fatal("test failed!")
+	} else if len(ts) != 2 || ts[1].UserName != "Hugo" || ts[1].Age != 2 {
+		t.Fatal("test failed!")
+	}
+	if _, err := ScanSelect[*testScanDTO]("TestScanSelect", "selectNow").One(); err == nil {
+		t.Fatal("test failed!")
+	}
+	if name, err := ScanSelect[string]("TestScanSelect", "selectName").One(); err != nil || name != "Haha" {
+		t.Fatal("test failed!")
+	}
+	if _, err := ScanSelect[testScanDTO]("TestScanSelect", "selectName").List(); err == nil {
+		t.Fatal("test failed!")
+	}
+}
@@ -13,25 +13,33 @@ package xmlquery
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
+	"github.com/go-the-way/anorm"
 	"text/template"
 )
 
 type (
 	SingleSelectable[T any] interface {
+		WithContext(ctx context.Context) SingleSelectable[T]
 		One(ps ...any) (T, error)
 		OneTemplate(data any) (T, error)
+		Explain(ps ...any) (*anorm.Plan, error)
 	}
 	singleSelectableImpl[T any] struct {
-		ds              string
-		db              *sql.DB
+		*nodeRunner
 		sqlStr, tSqlStr string
 	}
 )
 
 func SingleSelect[T any](namespace, id string) SingleSelectable[T] {
-	datasource, db, sqlStr := getNodeParams(namespace, id, selectType)
-	return &singleSelectableImpl[T]{datasource, db, sqlStr, ""}
+	runner, sqlStr := getNodeParams(namespace, id, selectType)
+	return &singleSelectableImpl[T]{runner, sqlStr, ""}
+}
+
+// WithContext set the context passed to the interceptors
+func (s *singleSelectableImpl[T]) WithContext(ctx context.Context) SingleSelectable[T] {
+	s.ctx = ctx
+	return s
 }
 
 func (s *singleSelectableImpl[T]) One(ps ...any) (t T, err error) {
@@ -39,9 +47,8 @@ func (s *singleSelectableImpl[T]) One(ps ...any) (t T, err error) {
 	if sqlStr == "" {
 		sqlStr = s.sqlStr
 	}
-	queryLog("SingleSelectable.One", sqlStr, ps...)
-	row := s.db.QueryRow(s.sqlStr, ps...)
-	if err2 := row.Err(); err2 != nil {
+	row, err2 := s.queryRow("SingleSelectable.One", sqlStr, ps...)
+	if err2 != nil {
 		err = err2
 		return
 	}
@@ -64,3 +71,12 @@ func (s *singleSelectableImpl[T]) OneTemplate(data any) (t T, err error) {
 	}
 	return
 }
+
+// Explain run the datasource dialect's EXPLAIN on the statement One would execute
+func (s *singleSelectableImpl[T]) Explain(ps ...any) (*anorm.Plan, error) {
+	sqlStr := s.tSqlStr
+	if sqlStr == "" {
+		sqlStr = s.sqlStr
+	}
+	return s.explain("SingleSelectable.One", sqlStr, ps...)
+}
@@ -102,6 +102,24 @@ func TestSingleSelectOneTemplate(t *testing.T) {
 	}
 }
 
+// OneTemplate queries the executed template, not the raw one
+func TestSingleSelectOneTemplateData(t *testing.T) {
+	var XML = `
+ <?xml version="1.0" encoding="utf-8"?>
+ <xmlquery namespace="TestSingleSelectOneTemplateData" datasource="">
+ 
+ 	<select id="selectNow">
+ 		select '{{.}}' as T
+ 	</select>
+ 	
+ </xmlquery>
+ `
+	BindXml(XML)
+	if tt, err := SingleSelect[string]("TestSingleSelectOneTemplateData", "selectNow").OneTemplate("Hugo"); err != nil || tt != "Hugo" {
+		t.Fatal("test failed!")
+	}
+}
+
 func TestSingleSelectOneTemplateParseErr(t *testing.T) {
 	var XML = `
  <?xml version="1.0" encoding="utf-8"?>
@@ -226,3 +226,22 @@ func TestSelectOneTemplateErrSelectTooManyResult(t *testing.T) {
 		t.Fatal("test failed!")
 	}
 }
+
+func TestSelectExplain(t *testing.T) {
+	var XML = `
+ <?xml version="1.0" encoding="utf-8"?>
+ <xmlquery namespace="TestSelectExplain" datasource="">
+ 
+ 	<select id="selectNow">
+ 		select 'Haha' as T union all select ?
+ 	</select>
+ 	
+ </xmlquery>
+ `
+	BindXml(XML)
+	if plan, err := Select[*testSelectE]("TestSelectExplain", "selectNow").Explain("Hugo"); err != nil {
+		t.Fatal("test failed!")
+	} else if len(plan.Rows) <= 0 || plan.FullScan {
+		t.Fatal("test failed!")
+	}
+}